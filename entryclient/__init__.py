@@ -4,18 +4,37 @@
 import codecs
 import errno
 import fcntl
+import hashlib
 import message_pb2
 import os
 import platform
 import select
 import signal
+import socket
 import ssl
 import struct
 import sys
 import termios
+import time
 import tty
 import websocket
 
+RESUME_RETRIES = 10
+RESUME_INTERVAL = 3  # seconds
+FILE_CHUNK_SIZE = 32 * 1024
+FORWARD_BUFFER_SIZE = 32 * 1024
+PROTOCOL_VERSION = 1
+CLIENT_FEATURES = ['resume', 'exit_status', 'instance_exit']
+FORWARDED_SIGNALS = {
+    signal.SIGINT: 'SIGINT',
+    signal.SIGTERM: 'SIGTERM',
+    signal.SIGQUIT: 'SIGQUIT',
+}
+
+
+class FileTransferError(Exception):
+    pass
+
 
 class EntryClient:
 
@@ -28,13 +47,43 @@ class EntryClient:
 
         self._oldtty = termios.tcgetattr(self._utf_in)
         self._old_handler = signal.getsignal(signal.SIGWINCH)
+        self._endpoint = endpoint
+        self._header = header
+        self._resume_token = None
+        self.exit_status = None
+        self.server_features = []
+        self.session_info = None
         try:
-            sslopt = {"cert_reqs": ssl.CERT_NONE}
-            self._ws = websocket.create_connection(
-                url=endpoint, header=header, sslopt=sslopt)
+            self._ws = self._connect(header)
         except:
             raise
 
+    def _connect(self, header):
+        sslopt = {"cert_reqs": ssl.CERT_NONE}
+        return websocket.create_connection(
+            url=self._endpoint, header=header, sslopt=sslopt)
+
+    def _resume(self):
+        if not self._resume_token:
+            return False
+
+        if isinstance(self._header, dict):
+            header = dict(self._header)
+            header['resume-token'] = self._resume_token
+        else:
+            header = list(self._header or [])
+            header.append('resume-token: %s' % self._resume_token)
+        for _ in range(RESUME_RETRIES):
+            time.sleep(RESUME_INTERVAL)
+            try:
+                self._ws = self._connect(header)
+            except (websocket.WebSocketException, socket.error):
+                continue
+            self._send_hello()
+            self._send_window_resize()
+            return True
+        return False
+
     def invoke_shell(self):
 
         def on_term_resize(signum, frame):
@@ -44,6 +93,8 @@ class EntryClient:
         try:
             tty.setraw(self._utf_in.fileno())
             tty.setcbreak(self._utf_in.fileno())
+            self._send_hello()
+            # Old servers ignore HELLO, so the window size is sent anyway
             self._send_window_resize()
             read_list = [self._ws.sock, self._utf_in]
 
@@ -51,7 +102,13 @@ class EntryClient:
                 try:
                     r, w, e = select.select(read_list, [], [])
                     if self._ws.sock in r:
-                        data = self._ws.recv()
+                        try:
+                            data = self._ws.recv()
+                        except (websocket.WebSocketException, socket.error):
+                            if not self._resume():
+                                raise
+                            read_list = [self._ws.sock, self._utf_in]
+                            continue
                         if self._is_close_message(data):
                             break
                     if self._utf_in in r:
@@ -65,7 +122,13 @@ class EntryClient:
                                 valid_utf8 = True
                             except UnicodeDecodeError:
                                 pass
-                        self._ws.send(self._gen_plain_request(utf8char))
+                        try:
+                            self._ws.send(self._gen_plain_request(utf8char))
+                        except (websocket.WebSocketException, socket.error):
+                            if not self._resume():
+                                raise
+                            read_list = [self._ws.sock, self._utf_in]
+                            self._ws.send(self._gen_plain_request(utf8char))
                 except (select.error, IOError) as e:
                     if e.args and e.args[0] == errno.EINTR:
                         pass
@@ -76,7 +139,12 @@ class EntryClient:
         finally:
             self._close()
 
-    def attach_container(self):
+    def attach_container(self, interactive=False):
+        """View the output of the container, or interact with it if the
+        connection is made with the `interactive: true` header"""
+        if interactive:
+            return self.invoke_shell()
+
         try:
             while True:
                 data = self._ws.recv()
@@ -87,6 +155,210 @@ class EntryClient:
         finally:
             self._close()
 
+    def send_signal(self, name):
+        """Send SIGINT, SIGTERM or SIGQUIT to the foreground processes"""
+        req_message = message_pb2.RequestMessage()
+        req_message.msgType = message_pb2.RequestMessage.SIGNAL
+        req_message.content = name
+        self._ws.send(req_message.SerializeToString())
+
+    def exec_command(self):
+        """Run the command given by the `command` header, return its exit code"""
+
+        def on_signal(signum, frame):
+            self.send_signal(FORWARDED_SIGNALS[signum])
+        old_handlers = {}
+        for signum in FORWARDED_SIGNALS:
+            old_handlers[signum] = signal.signal(signum, on_signal)
+
+        try:
+            while True:
+                resp_msg = self._gen_response(self._ws.recv())
+                if resp_msg.msgType == message_pb2.ResponseMessage.STDOUT:
+                    sys.stdout.write(resp_msg.content)
+                    sys.stdout.flush()
+                elif resp_msg.msgType == message_pb2.ResponseMessage.STDERR:
+                    sys.stderr.write(resp_msg.content)
+                    sys.stderr.flush()
+                elif resp_msg.msgType == message_pb2.ResponseMessage.CLOSE:
+                    if resp_msg.HasField('exitStatus'):
+                        self.exit_status = resp_msg.exitStatus
+                        return resp_msg.exitStatus.code
+                    self._utf_err.write(
+                        resp_msg.content.decode('utf-8', 'replace'))
+                    self._utf_err.flush()
+                    return -1
+        finally:
+            for signum, handler in old_handlers.items():
+                signal.signal(signum, handler)
+            self._ws.close()
+
+    def fanout_exec(self):
+        """Run the command on the instances selected by the `instance-no`
+        header, return the exit codes keyed by the instance numbers"""
+        exit_codes = {}
+        try:
+            # The server sends EXIT for each instance only after HELLO
+            self._send_hello()
+            while True:
+                resp_msg = self._gen_response(self._ws.recv())
+                prefix = '[%s] ' % resp_msg.instanceNo
+                if resp_msg.msgType == message_pb2.ResponseMessage.STDOUT:
+                    for line in resp_msg.content.splitlines(True):
+                        sys.stdout.write(prefix + line)
+                    sys.stdout.flush()
+                elif resp_msg.msgType == message_pb2.ResponseMessage.STDERR:
+                    for line in resp_msg.content.splitlines(True):
+                        sys.stderr.write(prefix + line)
+                    sys.stderr.flush()
+                elif resp_msg.msgType == message_pb2.ResponseMessage.EXIT:
+                    exit_codes[resp_msg.instanceNo] = resp_msg.exitStatus.code
+                elif resp_msg.msgType == message_pb2.ResponseMessage.SESSION_INFO:
+                    self.session_info = resp_msg.sessionInfo
+                elif resp_msg.msgType == message_pb2.ResponseMessage.CLOSE:
+                    if resp_msg.HasField('exitStatus'):
+                        self.exit_status = resp_msg.exitStatus
+                    self._utf_err.write(
+                        resp_msg.content.decode('utf-8', 'replace') + '\n')
+                    self._utf_err.flush()
+                    return exit_codes
+        finally:
+            self._ws.close()
+
+    def upload(self, local_path, remote_path):
+        checksum = hashlib.sha256()
+        with open(local_path, 'rb') as f:
+            for chunk in iter(lambda: f.read(FILE_CHUNK_SIZE), b''):
+                checksum.update(chunk)
+
+        try:
+            self._ws.send_binary(self._gen_file_request(
+                message_pb2.RequestMessage.UPLOAD_START, path=remote_path,
+                size=os.path.getsize(local_path),
+                checksum=checksum.hexdigest(),
+                mode=os.stat(local_path).st_mode & 0o7777))
+            offset = 0
+            with open(local_path, 'rb') as f:
+                for chunk in iter(lambda: f.read(FILE_CHUNK_SIZE), b''):
+                    self._ws.send_binary(self._gen_file_request(
+                        message_pb2.RequestMessage.UPLOAD_CHUNK,
+                        offset=offset, data=chunk,
+                        checksum=hashlib.sha256(chunk).hexdigest()))
+                    offset += len(chunk)
+            self._ws.send_binary(self._gen_file_request(
+                message_pb2.RequestMessage.UPLOAD_END))
+
+            while True:
+                resp_msg = self._recv_file_response()
+                if resp_msg.msgType == message_pb2.ResponseMessage.FILE_RESULT:
+                    if resp_msg.file.error:
+                        raise FileTransferError(resp_msg.file.error)
+                    return resp_msg.file.size
+        finally:
+            self._close()
+
+    def download(self, remote_path, local_path):
+        checksum = hashlib.sha256()
+        size = 0
+        try:
+            self._ws.send_binary(self._gen_file_request(
+                message_pb2.RequestMessage.DOWNLOAD, path=remote_path))
+            with open(local_path, 'wb') as f:
+                while True:
+                    resp_msg = self._recv_file_response()
+                    if resp_msg.msgType == message_pb2.ResponseMessage.FILE_CHUNK:
+                        chunk = resp_msg.file.data
+                        if (resp_msg.file.offset != size or
+                                hashlib.sha256(chunk).hexdigest() !=
+                                resp_msg.file.checksum):
+                            raise FileTransferError(
+                                'chunk at offset %d is corrupted' % size)
+                        f.write(chunk)
+                        checksum.update(chunk)
+                        size += len(chunk)
+                    elif resp_msg.msgType == message_pb2.ResponseMessage.FILE_RESULT:
+                        if resp_msg.file.error:
+                            raise FileTransferError(resp_msg.file.error)
+                        if resp_msg.file.checksum != checksum.hexdigest():
+                            raise FileTransferError('checksum mismatch')
+                        return size
+        finally:
+            self._close()
+
+    def forward(self, local_port, host='127.0.0.1'):
+        listener = socket.socket(socket.AF_INET, socket.SOCK_STREAM)
+        listener.setsockopt(socket.SOL_SOCKET, socket.SO_REUSEADDR, 1)
+        listener.bind((host, local_port))
+        listener.listen(5)
+        streams = {}  # stream id -> local connection
+        readers = {}  # local connection -> stream id
+        next_id = 1
+        try:
+            while True:
+                r, w, e = select.select(
+                    [self._ws.sock, listener] + list(readers), [], [])
+                if listener in r:
+                    conn, _ = listener.accept()
+                    streams[next_id] = conn
+                    readers[conn] = next_id
+                    self._ws.send_binary(self._gen_stream_request(
+                        message_pb2.RequestMessage.STREAM_OPEN, next_id))
+                    next_id += 1
+                if self._ws.sock in r:
+                    resp_msg = self._gen_response(self._ws.recv())
+                    stream_id = resp_msg.stream.id
+                    if resp_msg.msgType == message_pb2.ResponseMessage.CLOSE:
+                        self._utf_err.write(
+                            resp_msg.content.decode('utf-8', 'replace'))
+                        self._utf_err.flush()
+                        break
+                    elif (resp_msg.msgType ==
+                          message_pb2.ResponseMessage.STREAM_DATA and
+                          stream_id in streams):
+                        streams[stream_id].sendall(resp_msg.stream.data)
+                    elif (resp_msg.msgType ==
+                          message_pb2.ResponseMessage.STREAM_CLOSE and
+                          stream_id in streams):
+                        conn = streams.pop(stream_id)
+                        readers.pop(conn, None)
+                        conn.close()
+                        if resp_msg.stream.error:
+                            self._utf_err.write(
+                                'stream %d: %s\n' % (stream_id,
+                                                     resp_msg.stream.error))
+                            self._utf_err.flush()
+                for conn in [c for c in r if c in readers]:
+                    stream_id = readers[conn]
+                    try:
+                        data = conn.recv(FORWARD_BUFFER_SIZE)
+                    except socket.error:
+                        data = b''
+                    if data:
+                        self._ws.send_binary(self._gen_stream_request(
+                            message_pb2.RequestMessage.STREAM_DATA,
+                            stream_id, data))
+                    else:
+                        # Keep the connection until the server closes the stream
+                        del readers[conn]
+                        self._ws.send_binary(self._gen_stream_request(
+                            message_pb2.RequestMessage.STREAM_CLOSE,
+                            stream_id))
+        finally:
+            for conn in streams.values():
+                conn.close()
+            listener.close()
+            self._ws.close()
+
+    def _recv_file_response(self):
+        while True:
+            resp_msg = self._gen_response(self._ws.recv())
+            if resp_msg.msgType == message_pb2.ResponseMessage.CLOSE:
+                raise FileTransferError(
+                    resp_msg.content.decode('utf-8', 'replace'))
+            if resp_msg.msgType in (message_pb2.ResponseMessage.FILE_CHUNK,
+                                    message_pb2.ResponseMessage.FILE_RESULT):
+                return resp_msg
+
     def _close(self):
         termios.tcsetattr(self._utf_in, termios.TCSADRAIN, self._oldtty)
         signal.signal(signal.SIGWINCH, self._old_handler)
@@ -96,7 +368,27 @@ class EntryClient:
     def _is_close_message(self, msg):
         resp_msg = self._gen_response(msg)
         is_close = resp_msg.msgType == message_pb2.ResponseMessage.CLOSE
-        if resp_msg.msgType == message_pb2.ResponseMessage.STDOUT:
+        if is_close and resp_msg.HasField('exitStatus'):
+            self.exit_status = resp_msg.exitStatus
+        if resp_msg.msgType == message_pb2.ResponseMessage.RESUME_TOKEN:
+            self._resume_token = resp_msg.content
+        elif resp_msg.msgType == message_pb2.ResponseMessage.SESSION_INFO:
+            self.session_info = resp_msg.sessionInfo
+            info = '>>> Session: %d, instance: %s, container: %s, node: %s\r\n' % (
+                resp_msg.sessionInfo.sessionID,
+                resp_msg.sessionInfo.instanceNo,
+                resp_msg.sessionInfo.containerID[:12],
+                resp_msg.sessionInfo.nodeIP)
+            if resp_msg.sessionInfo.banner:
+                info += '>>> %s\r\n' % resp_msg.sessionInfo.banner
+            self._utf_err.write(info)
+            self._utf_err.flush()
+        elif resp_msg.msgType == message_pb2.ResponseMessage.READY:
+            self.server_features = list(resp_msg.ready.features)
+            if resp_msg.ready.error:
+                self._utf_err.write(resp_msg.ready.error + '\r\n')
+                self._utf_err.flush()
+        elif resp_msg.msgType == message_pb2.ResponseMessage.STDOUT:
             self._utf_out.write(resp_msg.content.decode('utf-8', 'replace'))
             self._utf_out.flush()
         elif (resp_msg.msgType == message_pb2.ResponseMessage.STDERR
@@ -117,6 +409,24 @@ class EntryClient:
         req_message.content = content
         return req_message.SerializeToString()
 
+    def _gen_file_request(self, msg_type, path='', size=0, offset=0,
+                          data=b'', checksum=''):
+        req_message = message_pb2.RequestMessage()
+        req_message.msgType = msg_type
+        req_message.file.path = path
+        req_message.file.size = size
+        req_message.file.offset = offset
+        req_message.file.data = data
+        req_message.file.checksum = checksum
+        return req_message.SerializeToString()
+
+    def _gen_stream_request(self, msg_type, stream_id, data=b''):
+        req_message = message_pb2.RequestMessage()
+        req_message.msgType = msg_type
+        req_message.stream.id = stream_id
+        req_message.stream.data = data
+        return req_message.SerializeToString()
+
     def _gen_response(self, payload):
         resp_message = message_pb2.ResponseMessage()
         resp_message.ParseFromString(payload)
@@ -127,11 +437,26 @@ class EntryClient:
         # Can't do much for Windows
         if platform.system() != 'Windows':
             fmt = 'HH'
-            result = fcntl.ioctl(
-                utf_out.fileno(), termios.TIOCGWINSZ, struct.pack(fmt, 0, 0))
+            try:
+                result = fcntl.ioctl(
+                    utf_out.fileno(), termios.TIOCGWINSZ, struct.pack(fmt, 0, 0))
+            except (IOError, OSError):
+                # The output is not a terminal, such as a pipe
+                return width, height
             height, width = struct.unpack(fmt, result)
         return width, height
 
+    def _send_hello(self):
+        width, height = self._get_window_size(self._utf_out)
+        req_message = message_pb2.RequestMessage()
+        req_message.msgType = message_pb2.RequestMessage.HELLO
+        req_message.hello.version = PROTOCOL_VERSION
+        req_message.hello.encoding = 'protobuf'
+        req_message.hello.width = width
+        req_message.hello.height = height
+        req_message.hello.features.extend(CLIENT_FEATURES)
+        self._ws.send(req_message.SerializeToString())
+
     def _send_window_resize(self):
         width, height = self._get_window_size(self._utf_out)
         self._ws.send(self._gen_resize_request(width, height))
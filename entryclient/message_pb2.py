@@ -19,7 +19,7 @@ DESCRIPTOR = _descriptor.FileDescriptor(
   name='message.proto',
   package='message',
   syntax='proto3',
-  serialized_pb=_b('\n\rmessage.proto\x12\x07message\"|\n\x0eRequestMessage\x12\x34\n\x07msgType\x18\x01 \x01(\x0e\x32#.message.RequestMessage.RequestType\x12\x0f\n\x07\x63ontent\x18\x02 \x01(\x0c\"#\n\x0bRequestType\x12\t\n\x05PLAIN\x10\x00\x12\t\n\x05WINCH\x10\x01\"\x97\x01\n\x0fResponseMessage\x12\x36\n\x07msgType\x18\x01 \x01(\x0e\x32%.message.ResponseMessage.ResponseType\x12\x0f\n\x07\x63ontent\x18\x02 \x01(\x0c\";\n\x0cResponseType\x12\n\n\x06STDOUT\x10\x00\x12\n\n\x06STDERR\x10\x01\x12\t\n\x05\x43LOSE\x10\x02\x12\x08\n\x04PING\x10\x03\x62\x06proto3')
+  serialized_pb=_b('\n\rmessage.proto\x12\x07message\"\xef\x02\n\x0eRequestMessage\x12\x34\n\x07msgType\x18\x01 \x01(\x0e\x32#.message.RequestMessage.RequestType\x12\x0f\n\x07\x63ontent\x18\x02 \x01(\x0c\x12#\n\x04\x66ile\x18\x03 \x01(\x0b\x32\x15.message.FileTransfer\x12\x1f\n\x06stream\x18\x04 \x01(\x0b\x32\x0f.message.Stream\x12\x1d\n\x05hello\x18\x05 \x01(\x0b\x32\x0e.message.Hello\"\xb0\x01\n\x0bRequestType\x12\t\n\x05PLAIN\x10\x00\x12\t\n\x05WINCH\x10\x01\x12\x10\n\x0cUPLOAD_START\x10\x02\x12\x10\n\x0cUPLOAD_CHUNK\x10\x03\x12\x0e\n\nUPLOAD_END\x10\x04\x12\x0c\n\x08\x44OWNLOAD\x10\x05\x12\x0f\n\x0bSTREAM_OPEN\x10\x06\x12\x0f\n\x0bSTREAM_DATA\x10\x07\x12\x10\n\x0cSTREAM_CLOSE\x10\x08\x12\t\n\x05HELLO\x10\t\x12\n\n\x06SIGNAL\x10\n\"\xe2\x03\n\x0fResponseMessage\x12\x36\n\x07msgType\x18\x01 \x01(\x0e\x32%.message.ResponseMessage.ResponseType\x12\x0f\n\x07\x63ontent\x18\x02 \x01(\x0c\x12#\n\x04\x66ile\x18\x03 \x01(\x0b\x32\x15.message.FileTransfer\x12\x1f\n\x06stream\x18\x04 \x01(\x0b\x32\x0f.message.Stream\x12\'\n\nexitStatus\x18\x05 \x01(\x0b\x32\x13.message.ExitStatus\x12\x1d\n\x05ready\x18\x06 \x01(\x0b\x32\x0e.message.Ready\x12)\n\x0bsessionInfo\x18\x07 \x01(\x0b\x32\x14.message.SessionInfo\x12\x12\n\ninstanceNo\x18\x08 \x01(\t\"\xb8\x01\n\x0cResponseType\x12\n\n\x06STDOUT\x10\x00\x12\n\n\x06STDERR\x10\x01\x12\t\n\x05\x43LOSE\x10\x02\x12\x08\n\x04PING\x10\x03\x12\x10\n\x0cRESUME_TOKEN\x10\x04\x12\x0e\n\nFILE_CHUNK\x10\x05\x12\x0f\n\x0b\x46ILE_RESULT\x10\x06\x12\x0f\n\x0bSTREAM_DATA\x10\x07\x12\x10\n\x0cSTREAM_CLOSE\x10\x08\x12\t\n\x05READY\x10\t\x12\x10\n\x0cSESSION_INFO\x10\n\x12\x08\n\x04\x45XIT\x10\x0b\"[\n\x05Hello\x12\x0f\n\x07version\x18\x01 \x01(\x05\x12\x10\n\x08\x65ncoding\x18\x02 \x01(\t\x12\r\n\x05width\x18\x03 \x01(\x05\x12\x0e\n\x06height\x18\x04 \x01(\x05\x12\x10\n\x08\x66\x65\x61tures\x18\x05 \x03(\t\"L\n\x05Ready\x12\x0f\n\x07version\x18\x01 \x01(\x05\x12\x11\n\tsessionID\x18\x02 \x01(\x03\x12\x10\n\x08\x66\x65\x61tures\x18\x03 \x03(\t\x12\r\n\x05\x65rror\x18\x04 \x01(\t\"w\n\x0c\x46ileTransfer\x12\x0c\n\x04path\x18\x01 \x01(\t\x12\x0c\n\x04size\x18\x02 \x01(\x03\x12\x0e\n\x06offset\x18\x03 \x01(\x03\x12\x0c\n\x04\x64\x61ta\x18\x04 \x01(\x0c\x12\x10\n\x08\x63hecksum\x18\x05 \x01(\t\x12\r\n\x05\x65rror\x18\x06 \x01(\t\x12\x0c\n\x04mode\x18\x07 \x01(\r\"1\n\x06Stream\x12\n\n\x02id\x18\x01 \x01(\x03\x12\x0c\n\x04\x64\x61ta\x18\x02 \x01(\x0c\x12\r\n\x05\x65rror\x18\x03 \x01(\t\"D\n\nExitStatus\x12\x0c\n\x04\x63ode\x18\x01 \x01(\x03\x12\x0e\n\x06reason\x18\x02 \x01(\t\x12\x18\n\x10\x63ontainerRunning\x18\x03 \x01(\x08\"i\n\x0bSessionInfo\x12\x11\n\tsessionID\x18\x01 \x01(\x03\x12\x13\n\x0b\x63ontainerID\x18\x02 \x01(\t\x12\x0e\n\x06nodeIP\x18\x03 \x01(\t\x12\x0e\n\x06\x62\x61nner\x18\x04 \x01(\t\x12\x12\n\ninstanceNo\x18\x05 \x01(\tb\x06proto3')
 )
 _sym_db.RegisterFileDescriptor(DESCRIPTOR)
 
@@ -39,11 +39,47 @@ _REQUESTMESSAGE_REQUESTTYPE = _descriptor.EnumDescriptor(
       name='WINCH', index=1, number=1,
       options=None,
       type=None),
+    _descriptor.EnumValueDescriptor(
+      name='UPLOAD_START', index=2, number=2,
+      options=None,
+      type=None),
+    _descriptor.EnumValueDescriptor(
+      name='UPLOAD_CHUNK', index=3, number=3,
+      options=None,
+      type=None),
+    _descriptor.EnumValueDescriptor(
+      name='UPLOAD_END', index=4, number=4,
+      options=None,
+      type=None),
+    _descriptor.EnumValueDescriptor(
+      name='DOWNLOAD', index=5, number=5,
+      options=None,
+      type=None),
+    _descriptor.EnumValueDescriptor(
+      name='STREAM_OPEN', index=6, number=6,
+      options=None,
+      type=None),
+    _descriptor.EnumValueDescriptor(
+      name='STREAM_DATA', index=7, number=7,
+      options=None,
+      type=None),
+    _descriptor.EnumValueDescriptor(
+      name='STREAM_CLOSE', index=8, number=8,
+      options=None,
+      type=None),
+    _descriptor.EnumValueDescriptor(
+      name='HELLO', index=9, number=9,
+      options=None,
+      type=None),
+    _descriptor.EnumValueDescriptor(
+      name='SIGNAL', index=10, number=10,
+      options=None,
+      type=None),
   ],
   containing_type=None,
   options=None,
-  serialized_start=115,
-  serialized_end=150,
+  serialized_start=218,
+  serialized_end=394,
 )
 _sym_db.RegisterEnumDescriptor(_REQUESTMESSAGE_REQUESTTYPE)
 
@@ -69,11 +105,43 @@ _RESPONSEMESSAGE_RESPONSETYPE = _descriptor.EnumDescriptor(
       name='PING', index=3, number=3,
       options=None,
       type=None),
+    _descriptor.EnumValueDescriptor(
+      name='RESUME_TOKEN', index=4, number=4,
+      options=None,
+      type=None),
+    _descriptor.EnumValueDescriptor(
+      name='FILE_CHUNK', index=5, number=5,
+      options=None,
+      type=None),
+    _descriptor.EnumValueDescriptor(
+      name='FILE_RESULT', index=6, number=6,
+      options=None,
+      type=None),
+    _descriptor.EnumValueDescriptor(
+      name='STREAM_DATA', index=7, number=7,
+      options=None,
+      type=None),
+    _descriptor.EnumValueDescriptor(
+      name='STREAM_CLOSE', index=8, number=8,
+      options=None,
+      type=None),
+    _descriptor.EnumValueDescriptor(
+      name='READY', index=9, number=9,
+      options=None,
+      type=None),
+    _descriptor.EnumValueDescriptor(
+      name='SESSION_INFO', index=10, number=10,
+      options=None,
+      type=None),
+    _descriptor.EnumValueDescriptor(
+      name='EXIT', index=11, number=11,
+      options=None,
+      type=None),
   ],
   containing_type=None,
   options=None,
-  serialized_start=245,
-  serialized_end=304,
+  serialized_start=695,
+  serialized_end=879,
 )
 _sym_db.RegisterEnumDescriptor(_RESPONSEMESSAGE_RESPONSETYPE)
 
@@ -99,6 +167,27 @@ _REQUESTMESSAGE = _descriptor.Descriptor(
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       options=None),
+    _descriptor.FieldDescriptor(
+      name='file', full_name='message.RequestMessage.file', index=2,
+      number=3, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='stream', full_name='message.RequestMessage.stream', index=3,
+      number=4, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='hello', full_name='message.RequestMessage.hello', index=4,
+      number=5, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
   ],
   extensions=[
   ],
@@ -112,8 +201,8 @@ _REQUESTMESSAGE = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=26,
-  serialized_end=150,
+  serialized_start=27,
+  serialized_end=394,
 )
 
 
@@ -138,6 +227,48 @@ _RESPONSEMESSAGE = _descriptor.Descriptor(
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       options=None),
+    _descriptor.FieldDescriptor(
+      name='file', full_name='message.ResponseMessage.file', index=2,
+      number=3, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='stream', full_name='message.ResponseMessage.stream', index=3,
+      number=4, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='exitStatus', full_name='message.ResponseMessage.exitStatus', index=4,
+      number=5, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='ready', full_name='message.ResponseMessage.ready', index=5,
+      number=6, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='sessionInfo', full_name='message.ResponseMessage.sessionInfo', index=6,
+      number=7, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='instanceNo', full_name='message.ResponseMessage.instanceNo', index=7,
+      number=8, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
   ],
   extensions=[
   ],
@@ -151,16 +282,363 @@ _RESPONSEMESSAGE = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=153,
-  serialized_end=304,
+  serialized_start=397,
+  serialized_end=879,
+)
+
+
+_HELLO = _descriptor.Descriptor(
+  name='Hello',
+  full_name='message.Hello',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='version', full_name='message.Hello.version', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='encoding', full_name='message.Hello.encoding', index=1,
+      number=2, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='width', full_name='message.Hello.width', index=2,
+      number=3, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='height', full_name='message.Hello.height', index=3,
+      number=4, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='features', full_name='message.Hello.features', index=4,
+      number=5, type=9, cpp_type=9, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=881,
+  serialized_end=972,
+)
+
+
+_READY = _descriptor.Descriptor(
+  name='Ready',
+  full_name='message.Ready',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='version', full_name='message.Ready.version', index=0,
+      number=1, type=5, cpp_type=1, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='sessionID', full_name='message.Ready.sessionID', index=1,
+      number=2, type=3, cpp_type=2, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='features', full_name='message.Ready.features', index=2,
+      number=3, type=9, cpp_type=9, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='error', full_name='message.Ready.error', index=3,
+      number=4, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=974,
+  serialized_end=1050,
+)
+
+
+_FILETRANSFER = _descriptor.Descriptor(
+  name='FileTransfer',
+  full_name='message.FileTransfer',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='path', full_name='message.FileTransfer.path', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='size', full_name='message.FileTransfer.size', index=1,
+      number=2, type=3, cpp_type=2, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='offset', full_name='message.FileTransfer.offset', index=2,
+      number=3, type=3, cpp_type=2, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='data', full_name='message.FileTransfer.data', index=3,
+      number=4, type=12, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b(""),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='checksum', full_name='message.FileTransfer.checksum', index=4,
+      number=5, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='error', full_name='message.FileTransfer.error', index=5,
+      number=6, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='mode', full_name='message.FileTransfer.mode', index=6,
+      number=7, type=13, cpp_type=3, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=1052,
+  serialized_end=1171,
+)
+
+
+_STREAM = _descriptor.Descriptor(
+  name='Stream',
+  full_name='message.Stream',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='id', full_name='message.Stream.id', index=0,
+      number=1, type=3, cpp_type=2, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='data', full_name='message.Stream.data', index=1,
+      number=2, type=12, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b(""),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='error', full_name='message.Stream.error', index=2,
+      number=3, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=1173,
+  serialized_end=1222,
+)
+
+
+_EXITSTATUS = _descriptor.Descriptor(
+  name='ExitStatus',
+  full_name='message.ExitStatus',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='code', full_name='message.ExitStatus.code', index=0,
+      number=1, type=3, cpp_type=2, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='reason', full_name='message.ExitStatus.reason', index=1,
+      number=2, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='containerRunning', full_name='message.ExitStatus.containerRunning', index=2,
+      number=3, type=8, cpp_type=7, label=1,
+      has_default_value=False, default_value=False,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=1224,
+  serialized_end=1292,
+)
+
+
+_SESSIONINFO = _descriptor.Descriptor(
+  name='SessionInfo',
+  full_name='message.SessionInfo',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='sessionID', full_name='message.SessionInfo.sessionID', index=0,
+      number=1, type=3, cpp_type=2, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='containerID', full_name='message.SessionInfo.containerID', index=1,
+      number=2, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='nodeIP', full_name='message.SessionInfo.nodeIP', index=2,
+      number=3, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='banner', full_name='message.SessionInfo.banner', index=3,
+      number=4, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='instanceNo', full_name='message.SessionInfo.instanceNo', index=4,
+      number=5, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=1294,
+  serialized_end=1399,
 )
 
 _REQUESTMESSAGE.fields_by_name['msgType'].enum_type = _REQUESTMESSAGE_REQUESTTYPE
+_REQUESTMESSAGE.fields_by_name['file'].message_type = _FILETRANSFER
+_REQUESTMESSAGE.fields_by_name['stream'].message_type = _STREAM
+_REQUESTMESSAGE.fields_by_name['hello'].message_type = _HELLO
 _REQUESTMESSAGE_REQUESTTYPE.containing_type = _REQUESTMESSAGE
 _RESPONSEMESSAGE.fields_by_name['msgType'].enum_type = _RESPONSEMESSAGE_RESPONSETYPE
+_RESPONSEMESSAGE.fields_by_name['file'].message_type = _FILETRANSFER
+_RESPONSEMESSAGE.fields_by_name['stream'].message_type = _STREAM
+_RESPONSEMESSAGE.fields_by_name['exitStatus'].message_type = _EXITSTATUS
+_RESPONSEMESSAGE.fields_by_name['ready'].message_type = _READY
+_RESPONSEMESSAGE.fields_by_name['sessionInfo'].message_type = _SESSIONINFO
 _RESPONSEMESSAGE_RESPONSETYPE.containing_type = _RESPONSEMESSAGE
 DESCRIPTOR.message_types_by_name['RequestMessage'] = _REQUESTMESSAGE
 DESCRIPTOR.message_types_by_name['ResponseMessage'] = _RESPONSEMESSAGE
+DESCRIPTOR.message_types_by_name['Hello'] = _HELLO
+DESCRIPTOR.message_types_by_name['Ready'] = _READY
+DESCRIPTOR.message_types_by_name['FileTransfer'] = _FILETRANSFER
+DESCRIPTOR.message_types_by_name['Stream'] = _STREAM
+DESCRIPTOR.message_types_by_name['ExitStatus'] = _EXITSTATUS
+DESCRIPTOR.message_types_by_name['SessionInfo'] = _SESSIONINFO
 
 RequestMessage = _reflection.GeneratedProtocolMessageType('RequestMessage', (_message.Message,), dict(
   DESCRIPTOR = _REQUESTMESSAGE,
@@ -176,5 +654,47 @@ ResponseMessage = _reflection.GeneratedProtocolMessageType('ResponseMessage', (_
   ))
 _sym_db.RegisterMessage(ResponseMessage)
 
+Hello = _reflection.GeneratedProtocolMessageType('Hello', (_message.Message,), dict(
+  DESCRIPTOR = _HELLO,
+  __module__ = 'message_pb2'
+  # @@protoc_insertion_point(class_scope:message.Hello)
+  ))
+_sym_db.RegisterMessage(Hello)
+
+Ready = _reflection.GeneratedProtocolMessageType('Ready', (_message.Message,), dict(
+  DESCRIPTOR = _READY,
+  __module__ = 'message_pb2'
+  # @@protoc_insertion_point(class_scope:message.Ready)
+  ))
+_sym_db.RegisterMessage(Ready)
+
+FileTransfer = _reflection.GeneratedProtocolMessageType('FileTransfer', (_message.Message,), dict(
+  DESCRIPTOR = _FILETRANSFER,
+  __module__ = 'message_pb2'
+  # @@protoc_insertion_point(class_scope:message.FileTransfer)
+  ))
+_sym_db.RegisterMessage(FileTransfer)
+
+Stream = _reflection.GeneratedProtocolMessageType('Stream', (_message.Message,), dict(
+  DESCRIPTOR = _STREAM,
+  __module__ = 'message_pb2'
+  # @@protoc_insertion_point(class_scope:message.Stream)
+  ))
+_sym_db.RegisterMessage(Stream)
+
+ExitStatus = _reflection.GeneratedProtocolMessageType('ExitStatus', (_message.Message,), dict(
+  DESCRIPTOR = _EXITSTATUS,
+  __module__ = 'message_pb2'
+  # @@protoc_insertion_point(class_scope:message.ExitStatus)
+  ))
+_sym_db.RegisterMessage(ExitStatus)
+
+SessionInfo = _reflection.GeneratedProtocolMessageType('SessionInfo', (_message.Message,), dict(
+  DESCRIPTOR = _SESSIONINFO,
+  __module__ = 'message_pb2'
+  # @@protoc_insertion_point(class_scope:message.SessionInfo)
+  ))
+_sym_db.RegisterMessage(SessionInfo)
+
 
 # @@protoc_insertion_point(module_scope)
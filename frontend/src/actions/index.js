@@ -11,9 +11,10 @@ export const changeFetchSessionsParameter = (name, value) => ({
   value
 })
 
-export const changeReplaySessionID = sessionID => ({
+export const changeReplaySessionID = (sessionID, isLive = false) => ({
   type: 'CHANGE_REPLAY_SESSION_ID',
-  sessionID
+  sessionID,
+  isLive
 })
 
 export const changeTabIndex = tabIndex => ({
@@ -33,6 +34,13 @@ export const fetchSessions = (offset, onFulfilled) => ({
   onFulfilled
 })
 
+export const terminateSession = (sessionID, reason, onFulfilled) => ({
+  type: 'TERMINATE_SESSION',
+  sessionID,
+  reason,
+  onFulfilled
+})
+
 export const searchCommandsInOneSession = (sessionID, since) => ({
   type: 'SEARCH_COMMANDS_IN_ONE_SESSION',
   sessionID,
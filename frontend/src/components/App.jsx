@@ -107,6 +107,7 @@ class App extends React.Component {
       classes,
       commandsCount,
       commandsRowsPerPage,
+      replaySessionIsLive,
       sessionsCount,
       sessionsRowsPerPage,
       tabIndex,
@@ -145,7 +146,7 @@ class App extends React.Component {
             <Tabs value={tabIndex} onChange={onChangeTabIndex}>
               <Tab label="Sessions" />
               <Tab label="Commands" />
-              {tabIndex === 2 && <Tab label="Replay" />}
+              {tabIndex === 2 && <Tab label={replaySessionIsLive ? 'Watch' : 'Replay'} />}
             </Tabs>
           </AppBar>
 
@@ -184,6 +185,7 @@ App.propTypes = {
   classes: PropTypes.object.isRequired,
   commandsCount: PropTypes.number.isRequired,
   commandsRowsPerPage: PropTypes.number.isRequired,
+  replaySessionIsLive: PropTypes.bool.isRequired,
   sessionsCount: PropTypes.number.isRequired,
   sessionsRowsPerPage: PropTypes.number.isRequired,
   tabIndex: PropTypes.number.isRequired,
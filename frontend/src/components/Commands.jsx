@@ -67,6 +67,10 @@ const Commands = ({
                     <TableCell padding="none">{n.user}</TableCell>
                     <TableCell padding="none">{n.appName}.{n.procName}.{n.instanceNo}</TableCell>
                     <TableCell padding="none">{n.content}</TableCell>
+                    <TableCell padding="none">{n.workDir}</TableCell>
+                    <TableCell numeric>{n.exitCode}</TableCell>
+                    <TableCell numeric>{n.duration}</TableCell>
+                    <TableCell padding="none">{n.pasted}</TableCell>
                     <TableCell numeric>{n.sessionID}</TableCell>
                     <TableCell padding="none">{format(n.createdAt, 'YYYY-MM-DD HH:mm:ss')}</TableCell>
                   </TableRow>
@@ -32,6 +32,30 @@ const columnData = [{
     disablePadding: true,
     label: 'Content'
   },
+  {
+    id: 'workDir',
+    numeric: false,
+    disablePadding: true,
+    label: 'Work Dir'
+  },
+  {
+    id: 'exitCode',
+    numeric: true,
+    disablePadding: false,
+    label: 'Exit Code'
+  },
+  {
+    id: 'duration',
+    numeric: true,
+    disablePadding: false,
+    label: 'Duration(ms)'
+  },
+  {
+    id: 'pasted',
+    numeric: false,
+    disablePadding: true,
+    label: 'Pasted'
+  },
   {
     id: 'sessionID',
     numeric: true,
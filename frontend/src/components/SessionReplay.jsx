@@ -81,6 +81,7 @@ class SessionReplay extends React.Component {
       term
     } = this.state;
     const {
+      isLive,
       sessionID
     } = this.props;
 
@@ -91,7 +92,7 @@ class SessionReplay extends React.Component {
     term.focus();
 
     let replayURI = 'wss://' + window.location.host + '/api/sessions/';
-    replayURI += sessionID + '/replay';
+    replayURI += sessionID + (isLive ? '/watch' : '/replay');
     let ws = new WebSocket(replayURI);
     this.setState({
       ws: ws
@@ -99,7 +100,8 @@ class SessionReplay extends React.Component {
 
     ws.onopen = () => {
       console.info('WebSocket is open...');
-      term.writeln(printInfo('Session replay started...'));
+      term.writeln(printInfo(isLive ? 'Session watching started...' :
+        'Session replay started...'));
     };
 
     ws.onclose = () => {
@@ -149,6 +151,7 @@ class SessionReplay extends React.Component {
   render() {
     const {
       classes,
+      isLive,
       sessionID
     } = this.props;
 
@@ -157,7 +160,7 @@ class SessionReplay extends React.Component {
         <Card className={classes.card}
         >
           <CardHeader
-            title={'Replay Session: ' + sessionID}
+            title={(isLive ? 'Watch Session: ' : 'Replay Session: ') + sessionID}
           >
           </CardHeader>
 
@@ -185,6 +188,7 @@ class SessionReplay extends React.Component {
 
 SessionReplay.propTypes = {
   classes: PropTypes.object.isRequired,
+  isLive: PropTypes.bool.isRequired,
   sessionID: PropTypes.number.isRequired,
 }
 
@@ -13,8 +13,10 @@ import Table, {
 import Paper from 'material-ui/Paper';
 import Tooltip from 'material-ui/Tooltip';
 import IconButton from 'material-ui/IconButton';
+import CancelIcon from 'material-ui-icons/Cancel';
 import ReplayIcon from 'material-ui-icons/Replay';
 import SearchIcon from 'material-ui-icons/Search';
+import VisibilityIcon from 'material-ui-icons/Visibility';
 import {
   format
 } from 'date-fns';
@@ -49,7 +51,9 @@ const Sessions = ({
   onChangePage,
   onChangeRowsPerPage,
   onReplay,
-  onSearchCommands
+  onSearchCommands,
+  onTerminate,
+  onWatch
 }) => (
   <div>
     <div style={queryStyle}>
@@ -75,9 +79,15 @@ const Sessions = ({
                     <TableCell numeric>{n.sessionID}</TableCell>
                     <TableCell padding="none">{n.user}</TableCell>
                     <TableCell padding="none">{n.sourceIP}</TableCell>
-                    <TableCell padding="none">{n.appName}.{n.procName}.{n.instanceNo}</TableCell>
+                    <TableCell padding="none">
+                      {n.appName}.{n.procName}.{n.instanceNo}{n.type === 'forward' && ':' + n.targetPort}
+                    </TableCell>
                     <TableCell padding="none">{n.nodeIP}</TableCell>
-                    <TableCell padding="none">{n.status}</TableCell>
+                    <TableCell padding="none">
+                      {n.terminatedBy ? n.status + ' (terminated by ' + n.terminatedBy + ')' : n.status}
+                      {n.exitReason && ' [' + n.exitReason + ', exit code: ' + (n.exitCode || 0) + ']'}
+                      {n.secretEntered && ' [secret entered]'}
+                    </TableCell>
                     <TableCell padding="none">{format(n.createdAt, 'YYYY-MM-DD HH:mm:ss')}</TableCell>
                     <TableCell padding="none">{format(n.endedAt, 'YYYY-MM-DD HH:mm:ss')}</TableCell>
                     <TableCell padding="none">
@@ -91,6 +101,7 @@ const Sessions = ({
                         </IconButton>
                       </Tooltip>
 
+                      {(n.type === 'enter' || n.type === 'attach') &&
                       <Tooltip title="Replay">
                         <IconButton
                           className={classes.button}
@@ -100,6 +111,31 @@ const Sessions = ({
                           <ReplayIcon />
                         </IconButton>
                       </Tooltip>
+                      }
+
+                      {n.status === 'active' && (n.type === 'enter' || n.type === 'attach') &&
+                      <Tooltip title="Watch">
+                        <IconButton
+                          className={classes.button}
+                          aria-label="Watch"
+                          onClick={() => onWatch(n.sessionID)}
+                        >
+                          <VisibilityIcon />
+                        </IconButton>
+                      </Tooltip>
+                      }
+
+                      {n.status === 'active' &&
+                      <Tooltip title="Terminate">
+                        <IconButton
+                          className={classes.button}
+                          aria-label="Terminate"
+                          onClick={() => onTerminate(n.sessionID)}
+                        >
+                          <CancelIcon />
+                        </IconButton>
+                      </Tooltip>
+                      }
                     </TableCell>
                   </TableRow>
                 );
@@ -142,7 +178,9 @@ Sessions.propTypes = {
   onChangePage: PropTypes.func.isRequired,
   onChangeRowsPerPage: PropTypes.func.isRequired,
   onReplay: PropTypes.func.isRequired,
-  onSearchCommands: PropTypes.func.isRequired
+  onSearchCommands: PropTypes.func.isRequired,
+  onTerminate: PropTypes.func.isRequired,
+  onWatch: PropTypes.func.isRequired
 };
 
 export default withStyles(styles)(Sessions);
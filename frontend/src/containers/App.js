@@ -10,6 +10,7 @@ import App from '../components/App.jsx'
 const mapStateToProps = state => ({
   commandsCount: state.commands.data.length,
   commandsRowsPerPage: state.commands.rowsPerPage,
+  replaySessionIsLive: state.app.replaySessionIsLive,
   sessionsCount: state.sessions.data.length,
   sessionsRowsPerPage: state.sessions.rowsPerPage,
   tabIndex: state.app.tabIndex
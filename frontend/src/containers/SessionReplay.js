@@ -5,7 +5,8 @@ import {
 import SessionReplay from '../components/SessionReplay.jsx'
 
 const mapStateToProps = state => ({
-  sessionID: state.app.replaySessionID
+  sessionID: state.app.replaySessionID,
+  isLive: state.app.replaySessionIsLive
 })
 
 export default connect(mapStateToProps)(SessionReplay)
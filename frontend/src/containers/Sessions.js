@@ -9,7 +9,8 @@ import {
   changeTabIndex,
   fetchSessions,
   onFulfilledFetchSessions,
-  searchCommandsInOneSession
+  searchCommandsInOneSession,
+  terminateSession
 } from '../actions'
 import Sessions from '../components/Sessions.jsx'
 import {
@@ -39,8 +40,23 @@ const mapDispatchToProps = (dispatch, ownProps) => ({
     dispatch(changeReplaySessionID(sessionID))
     dispatch(changeTabIndex(2))
   },
+  onWatch: sessionID => {
+    dispatch(changeReplaySessionID(sessionID, true))
+    dispatch(changeTabIndex(2))
+  },
   onSearchCommands: (sessionID, since) => dispatch(
-    searchCommandsInOneSession(sessionID, since))
+    searchCommandsInOneSession(sessionID, since)),
+  onTerminate: sessionID => {
+    const reason = window.prompt('Terminate session ' + sessionID +
+      ', the reason will be shown to the user:')
+    if (reason === null) {
+      return
+    }
+
+    dispatch(terminateSession(sessionID, reason, () =>
+      dispatch(fetchSessions(0, offset => response =>
+        dispatch(onFulfilledFetchSessions(offset)(response))))))
+  }
 })
 
 export default connect(mapStateToProps, mapDispatchToProps)(Sessions)
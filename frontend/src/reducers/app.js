@@ -1,6 +1,7 @@
 import {
   LIMIT,
-  get
+  get,
+  post
 } from './myAxios'
 
 const params = new URLSearchParams(window.location.search.substring(1))
@@ -21,6 +22,7 @@ const defaultState = {
     new Date(fetchSessionsParameter_since * 1000) : new Date(),
   fetchSessionsParameter_user: '',
   replaySessionID: '',
+  replaySessionIsLive: false,
   tabIndex: 0
 }
 
@@ -37,7 +39,8 @@ const app = (state = defaultState, action) => {
       }
     case 'CHANGE_REPLAY_SESSION_ID':
       return { ...state,
-        replaySessionID: action.sessionID
+        replaySessionID: action.sessionID,
+        replaySessionIsLive: action.isLive
       }
     case 'CHANGE_TAB_INDEX':
       return { ...state,
@@ -90,6 +93,13 @@ const app = (state = defaultState, action) => {
         fetchCommandsParameter_sessionID: action.sessionID,
         fetchCommandsParameter_since: action.since
       }
+    case 'TERMINATE_SESSION':
+      post('/api/sessions/' + action.sessionID + '/terminate', action.onFulfilled, {
+        params: action.reason ? {
+          reason: action.reason
+        } : {}
+      })
+      return state
     default:
       return state
   }
@@ -34,6 +34,10 @@ const commands = (state = defaultState, action) => {
             procName: x.proc_name,
             instanceNo: x.instance_no,
             content: x.content,
+            workDir: x.work_dir || '',
+            exitCode: x.captured ? (x.exit_code || 0) : '',
+            duration: x.captured ? (x.duration || 0) : '',
+            pasted: x.pasted ? 'Yes' : '',
             sessionID: x.session_id,
             createdAt: new Date(x.created_at * 1000)
           }))
@@ -8,15 +8,23 @@ const myAxios = axios.create({
 
 export const LIMIT = 200
 
+const onRejected = err => {
+  if (err.response.status === 401) {
+    window.alert('Please login with an account who own Entry.')
+    return
+  }
+
+  console.error('error', err.response)
+}
+
 export const get = (url, f, config = {}) => {
   return myAxios.get(url, config)
     .then(f)
-    .catch(err => {
-      if (err.response.status === 401) {
-        window.alert('Please login with an account who own Entry.')
-        return
-      }
+    .catch(onRejected)
+}
 
-      console.error('error', err.response)
-    })
+export const post = (url, f, config = {}) => {
+  return myAxios.post(url, null, config)
+    .then(f)
+    .catch(onRejected)
 }
@@ -36,6 +36,12 @@ const sessions = (state = defaultState, action) => {
             instanceNo: x.instance_no,
             nodeIP: x.node_ip,
             status: x.status,
+            terminatedBy: x.terminated_by,
+            type: x.type,
+            targetPort: x.target_port,
+            exitCode: x.exit_code,
+            exitReason: x.exit_reason,
+            secretEntered: x.secret_entered || false,
             createdAt: new Date(x.created_at * 1000),
             endedAt: new Date(x.ended_at * 1000)
           }))
@@ -4,18 +4,34 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"time"
 
 	swaggermodels "github.com/laincloud/entry/server/gen/models"
 )
 
-// WriteBufferSize assign the websocket write buffer size
-const WriteBufferSize = 10240
+const (
+	// WriteBufferSize assign the websocket write buffer size
+	WriteBufferSize = 10240
+	// CommandCaptureKeystroke rebuild the commands from the keystrokes of the user
+	CommandCaptureKeystroke = "keystroke"
+	// CommandCaptureShell capture the commands by the hook injected into bash, the keystrokes are used for other shells
+	CommandCaptureShell = "shell"
+	// CommandCaptureScreen read the commands from the screen emulated by entry with the output of the shell
+	CommandCaptureScreen = "screen"
+
+	defaultCleanupGracePeriod = 5 * time.Second
+	defaultResumeGracePeriod  = 60 * time.Second
+	defaultTimeoutWarning     = 60 * time.Second
+	defaultMaxTransferSizeMB  = 1024
+)
 
 // Config denotes configuration
 type Config struct {
-	MySQL MySQL `json:"mysql"`
-	SMTP  SMTP  `json:"smtp"`
-	SSO   SSO   `json:"sso"`
+	Apps    map[string]App `json:"apps"`
+	MySQL   MySQL          `json:"mysql"`
+	Session Session        `json:"session"`
+	SMTP    SMTP           `json:"smtp"`
+	SSO     SSO            `json:"sso"`
 }
 
 // NewConfig return an initialized configuration
@@ -46,6 +62,60 @@ func (c Config) SwaggerModel() swaggermodels.Config {
 	}
 }
 
+// Banner return the banner shown to the users entering the app, the banner of the app takes precedence
+func (c Config) Banner(appName string) string {
+	if banner := c.Apps[appName].Banner; banner != "" {
+		return banner
+	}
+
+	return c.Session.Banner
+}
+
+// CommandCapture return how the commands in the sessions of the app are captured, the mode of the app takes precedence
+func (c Config) CommandCapture(appName string) string {
+	if mode := c.Apps[appName].CommandCapture; mode != "" {
+		return mode
+	}
+
+	if c.Session.CommandCapture != "" {
+		return c.Session.CommandCapture
+	}
+
+	return CommandCaptureKeystroke
+}
+
+// IdleTimeout return how long a session of the app can go without input, 0 means no limit
+func (c Config) IdleTimeout(appName string) time.Duration {
+	return seconds(c.Session.IdleTimeoutSeconds, c.Apps[appName].IdleTimeoutSeconds)
+}
+
+// MaxDuration return how long a session of the app can last, 0 means no limit
+func (c Config) MaxDuration(appName string) time.Duration {
+	return seconds(c.Session.MaxDurationSeconds, c.Apps[appName].MaxDurationSeconds)
+}
+
+// seconds return the app value if it is set, negative values mean no limit
+func seconds(global, app int) time.Duration {
+	if app != 0 {
+		global = app
+	}
+
+	if global <= 0 {
+		return 0
+	}
+
+	return time.Duration(global) * time.Second
+}
+
+// App denotes per-app configuration, the key of Config.Apps is the app name
+type App struct {
+	Banner             string `json:"banner"`
+	Shell              string `json:"shell"`
+	IdleTimeoutSeconds int    `json:"idle_timeout_seconds"`
+	MaxDurationSeconds int    `json:"max_duration_seconds"`
+	CommandCapture     string `json:"command_capture"`
+}
+
 // MySQL denotes MySQL configuration
 type MySQL struct {
 	Username string `json:"username"`
@@ -60,6 +130,54 @@ func (m MySQL) DataSourceName() string {
 	return fmt.Sprintf("%s:%s@tcp(%s:%d)/%s?charset=utf8mb4&parseTime=True&loc=Local", m.Username, m.Password, m.Host, m.Port, m.DBName)
 }
 
+// Session denotes session configuration
+type Session struct {
+	CleanupGracePeriodSeconds int    `json:"cleanup_grace_period_seconds"`
+	ResumeGracePeriodSeconds  int    `json:"resume_grace_period_seconds"`
+	IdleTimeoutSeconds        int    `json:"idle_timeout_seconds"`
+	MaxDurationSeconds        int    `json:"max_duration_seconds"`
+	TimeoutWarningSeconds     int    `json:"timeout_warning_seconds"`
+	Banner                    string `json:"banner"`
+	CommandCapture            string `json:"command_capture"`
+	MaxTransferSizeMB         int64  `json:"max_transfer_size_mb"`
+}
+
+// CleanupGracePeriod return how long to wait between SIGHUP and SIGKILL when cleaning up the shell
+func (s Session) CleanupGracePeriod() time.Duration {
+	if s.CleanupGracePeriodSeconds <= 0 {
+		return defaultCleanupGracePeriod
+	}
+
+	return time.Duration(s.CleanupGracePeriodSeconds) * time.Second
+}
+
+// ResumeGracePeriod return how long to keep the shell alive for the user to resume after the websocket drops
+func (s Session) ResumeGracePeriod() time.Duration {
+	if s.ResumeGracePeriodSeconds <= 0 {
+		return defaultResumeGracePeriod
+	}
+
+	return time.Duration(s.ResumeGracePeriodSeconds) * time.Second
+}
+
+// TimeoutWarning return how long before the idle timeout or the max duration to warn the user
+func (s Session) TimeoutWarning() time.Duration {
+	if s.TimeoutWarningSeconds <= 0 {
+		return defaultTimeoutWarning
+	}
+
+	return time.Duration(s.TimeoutWarningSeconds) * time.Second
+}
+
+// MaxTransferSize return the largest file in bytes which can be uploaded or downloaded
+func (s Session) MaxTransferSize() int64 {
+	if s.MaxTransferSizeMB <= 0 {
+		return defaultMaxTransferSizeMB << 20
+	}
+
+	return s.MaxTransferSizeMB << 20
+}
+
 // SSO denotes SSO configuration
 type SSO struct {
 	Domain       string `json:"domain"`
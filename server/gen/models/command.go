@@ -19,6 +19,9 @@ type Command struct {
 	// app name
 	AppName string `json:"app_name,omitempty"`
 
+	// Whether the command is captured by the shell hook rather than rebuilt from the keystrokes
+	Captured bool `json:"captured,omitempty"`
+
 	// command id
 	// Read Only: true
 	CommandID int64 `json:"command_id,omitempty"`
@@ -29,9 +32,18 @@ type Command struct {
 	// Unix timestamp(unit: second)
 	CreatedAt int64 `json:"created_at,omitempty"`
 
+	// How long the command runs(unit: millisecond), only known if the command is captured
+	Duration int64 `json:"duration,omitempty"`
+
+	// -1 if the command has not exited, only known if the command is captured
+	ExitCode int64 `json:"exit_code,omitempty"`
+
 	// instance no
 	InstanceNo string `json:"instance_no,omitempty"`
 
+	// Whether the command is pasted rather than typed, only known if the command is rebuilt from the keystrokes
+	Pasted bool `json:"pasted,omitempty"`
+
 	// proc name
 	ProcName string `json:"proc_name,omitempty"`
 
@@ -41,6 +53,9 @@ type Command struct {
 
 	// user
 	User string `json:"user,omitempty"`
+
+	// The work directory where the command runs, only known if the command is captured
+	WorkDir string `json:"work_dir,omitempty"`
 }
 
 // Validate validates this command
@@ -19,34 +19,70 @@ type Session struct {
 	// app name
 	AppName string `json:"app_name,omitempty"`
 
+	// bytes sent to the container, only for forward sessions
+	BytesIn int64 `json:"bytes_in,omitempty"`
+
+	// bytes received from the container, only for forward sessions
+	BytesOut int64 `json:"bytes_out,omitempty"`
+
+	// whether the shell has been cleaned up when the session ended, succeeded, failed, or unknown if no process of the session is found
+	CleanupStatus string `json:"cleanup_status,omitempty"`
+
 	// container id
 	ContainerID string `json:"container_id,omitempty"`
 
+	// whether the container was still running when the session ended
+	ContainerRunning bool `json:"container_running,omitempty"`
+
 	// Unix timestamp(unit: second)
 	CreatedAt int64 `json:"created_at,omitempty"`
 
 	// Unix timestamp(unit: second)
 	EndedAt int64 `json:"ended_at,omitempty"`
 
+	// the exit code of the shell or the command, -1 if it is unknown
+	ExitCode int64 `json:"exit_code,omitempty"`
+
+	// why the session ended, exited, error, terminated, disconnected, canceled, idle_timeout or max_duration
+	ExitReason string `json:"exit_reason,omitempty"`
+
 	// instance no
 	InstanceNo string `json:"instance_no,omitempty"`
 
 	// node ip
 	NodeIP string `json:"node_ip,omitempty"`
 
+	// the fan-out session which the exec session belongs to, 0 if there is none
+	ParentID int64 `json:"parent_id,omitempty"`
+
 	// proc name
 	ProcName string `json:"proc_name,omitempty"`
 
+	// whether a secret such as a password was typed with echo disabled, which is not recorded as a command
+	SecretEntered bool `json:"secret_entered,omitempty"`
+
 	// session id
 	// Read Only: true
 	SessionID int64 `json:"session_id,omitempty"`
 
+	// the shell which is chosen for enter sessions
+	Shell string `json:"shell,omitempty"`
+
 	// source ip
 	SourceIP string `json:"source_ip,omitempty"`
 
 	// status
 	Status string `json:"status,omitempty"`
 
+	// the port inside the container, only for forward sessions
+	TargetPort int64 `json:"target_port,omitempty"`
+
+	// the entry owner who terminated the session
+	TerminatedBy string `json:"terminated_by,omitempty"`
+
+	// enter, forward, exec, attach or fanout
+	Type string `json:"type,omitempty"`
+
 	// user
 	User string `json:"user,omitempty"`
 }
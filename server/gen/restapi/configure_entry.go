@@ -26,7 +26,9 @@ import (
 	"github.com/laincloud/entry/server/gen/restapi/operations/commands"
 	swaggerconfig "github.com/laincloud/entry/server/gen/restapi/operations/config"
 	"github.com/laincloud/entry/server/gen/restapi/operations/container"
+	"github.com/laincloud/entry/server/gen/restapi/operations/file_transfers"
 	"github.com/laincloud/entry/server/gen/restapi/operations/ping"
+	"github.com/laincloud/entry/server/gen/restapi/operations/session_events"
 	"github.com/laincloud/entry/server/gen/restapi/operations/sessions"
 	"github.com/laincloud/entry/server/global"
 	"github.com/laincloud/entry/server/handler"
@@ -102,6 +104,18 @@ func configureAPI(api *operations.EntryAPI) http.Handler {
 	api.ContainerEnterContainerHandler = container.EnterContainerHandlerFunc(func(params container.EnterContainerParams) middleware.Responder {
 		return handler.HandleWebsocket(ctx, handler.Enter, params.HTTPRequest, g)
 	})
+	api.ContainerExecCommandHandler = container.ExecCommandHandlerFunc(func(params container.ExecCommandParams) middleware.Responder {
+		return handler.HandleWebsocket(ctx, handler.Exec, params.HTTPRequest, g)
+	})
+	api.ContainerFanoutExecHandler = container.FanoutExecHandlerFunc(func(params container.FanoutExecParams) middleware.Responder {
+		return handler.HandleWebsocket(ctx, handler.FanoutExec, params.HTTPRequest, g)
+	})
+	api.ContainerStreamProcLogsHandler = container.StreamProcLogsHandlerFunc(func(params container.StreamProcLogsParams) middleware.Responder {
+		return handler.HandleWebsocket(ctx, handler.ProcLogs, params.HTTPRequest, g)
+	})
+	api.ContainerForwardPortHandler = container.ForwardPortHandlerFunc(func(params container.ForwardPortParams) middleware.Responder {
+		return handler.HandleWebsocket(ctx, handler.Forward, params.HTTPRequest, g)
+	})
 
 	api.PingPingHandler = ping.PingHandlerFunc(handler.Ping)
 	api.AuthAuthorizeHandler = auth.AuthorizeHandlerFunc(func(params auth.AuthorizeParams) middleware.Responder {
@@ -116,12 +130,35 @@ func configureAPI(api *operations.EntryAPI) http.Handler {
 	api.CommandsListCommandsHandler = commands.ListCommandsHandlerFunc(func(params commands.ListCommandsParams) middleware.Responder {
 		return handler.ListCommands(params, g)
 	})
+	api.FileTransfersListFileTransfersHandler = file_transfers.ListFileTransfersHandlerFunc(func(params file_transfers.ListFileTransfersParams) middleware.Responder {
+		return handler.ListFileTransfers(params, g)
+	})
+	api.SessionEventsListSessionEventsHandler = session_events.ListSessionEventsHandlerFunc(func(params session_events.ListSessionEventsParams) middleware.Responder {
+		return handler.ListSessionEvents(params, g)
+	})
 	api.SessionsListSessionsHandler = sessions.ListSessionsHandlerFunc(func(params sessions.ListSessionsParams) middleware.Responder {
 		return handler.ListSessions(params, g)
 	})
+	api.SessionsGetSessionScreenHandler = sessions.GetSessionScreenHandlerFunc(func(params sessions.GetSessionScreenParams) middleware.Responder {
+		return handler.GetSessionScreen(params, g)
+	})
 	api.SessionsReplaySessionHandler = sessions.ReplaySessionHandlerFunc(func(params sessions.ReplaySessionParams) middleware.Responder {
 		return handler.HandleWebsocket(ctx, handler.ReplaySession, params.HTTPRequest, g)
 	})
+	api.SessionsTerminateSessionHandler = sessions.TerminateSessionHandlerFunc(func(params sessions.TerminateSessionParams) middleware.Responder {
+		return handler.TerminateSession(params, g)
+	})
+	api.SessionsTerminateSessionByPostHandler = sessions.TerminateSessionByPostHandlerFunc(func(params sessions.TerminateSessionByPostParams) middleware.Responder {
+		return handler.TerminateSession(sessions.TerminateSessionParams{
+			HTTPRequest: params.HTTPRequest,
+			Cookie:      params.Cookie,
+			Reason:      params.Reason,
+			SessionID:   params.SessionID,
+		}, g)
+	})
+	api.SessionsWatchSessionHandler = sessions.WatchSessionHandlerFunc(func(params sessions.WatchSessionParams) middleware.Responder {
+		return handler.HandleWebsocket(ctx, handler.WatchSession, params.HTTPRequest, g)
+	})
 
 	api.ServerShutdown = func() {
 		cancel()
@@ -173,6 +173,106 @@ func init() {
         }
       }
     },
+    "/api/exec": {
+      "get": {
+        "tags": [
+          "container"
+        ],
+        "operationId": "execCommand",
+        "responses": {
+          "200": {
+            "description": "run a command in the container without tty"
+          }
+        }
+      }
+    },
+    "/api/fanout_exec": {
+      "get": {
+        "tags": [
+          "container"
+        ],
+        "operationId": "fanoutExec",
+        "responses": {
+          "200": {
+            "description": "run a command on the selected instances of the proc without tty"
+          }
+        }
+      }
+    },
+    "/api/file_transfers": {
+      "get": {
+        "tags": [
+          "file_transfers"
+        ],
+        "operationId": "listFileTransfers",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Cookie with access_token",
+            "name": "Cookie",
+            "in": "header",
+            "required": true
+          },
+          {
+            "type": "integer",
+            "format": "int64",
+            "default": 0,
+            "description": "Unix timestamp(unit: second)",
+            "name": "since",
+            "in": "query"
+          },
+          {
+            "type": "integer",
+            "format": "int64",
+            "default": 20,
+            "name": "limit",
+            "in": "query"
+          },
+          {
+            "type": "integer",
+            "format": "int64",
+            "default": 0,
+            "name": "offset",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "MySQL LIKE pattern match",
+            "name": "user",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "MySQL LIKE pattern match",
+            "name": "app_name",
+            "in": "query"
+          },
+          {
+            "type": "integer",
+            "format": "int64",
+            "name": "session_id",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "list the file transfers",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/file_transfer"
+              }
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
     "/api/logout": {
       "get": {
         "tags": [
@@ -220,6 +320,98 @@ func init() {
         }
       }
     },
+    "/api/proc_logs": {
+      "get": {
+        "tags": [
+          "container"
+        ],
+        "operationId": "streamProcLogs",
+        "responses": {
+          "200": {
+            "description": "stream the logs of all instances of the proc"
+          }
+        }
+      }
+    },
+    "/api/session_events": {
+      "get": {
+        "tags": [
+          "session_events"
+        ],
+        "operationId": "listSessionEvents",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Cookie with access_token",
+            "name": "Cookie",
+            "in": "header",
+            "required": true
+          },
+          {
+            "type": "integer",
+            "format": "int64",
+            "default": 0,
+            "description": "Unix timestamp(unit: second)",
+            "name": "since",
+            "in": "query"
+          },
+          {
+            "type": "integer",
+            "format": "int64",
+            "default": 20,
+            "name": "limit",
+            "in": "query"
+          },
+          {
+            "type": "integer",
+            "format": "int64",
+            "default": 0,
+            "name": "offset",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "MySQL LIKE pattern match",
+            "name": "user",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "MySQL LIKE pattern match",
+            "name": "app_name",
+            "in": "query"
+          },
+          {
+            "type": "integer",
+            "format": "int64",
+            "name": "session_id",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "name": "type",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "list the audit events of sessions",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/session_event"
+              }
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
     "/api/sessions": {
       "get": {
         "tags": [
@@ -273,6 +465,19 @@ func init() {
             "description": "MySQL LIKE pattern match",
             "name": "app_name",
             "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "enter, forward, exec, attach or fanout",
+            "name": "type",
+            "in": "query"
+          },
+          {
+            "type": "integer",
+            "format": "int64",
+            "description": "list the exec sessions of the fan-out session",
+            "name": "parent_id",
+            "in": "query"
           }
         ],
         "responses": {
@@ -294,15 +499,48 @@ func init() {
         }
       }
     },
-    "/api/sessions/{session_id}/replay": {
-      "get": {
+    "/api/sessions/{session_id}": {
+      "delete": {
         "tags": [
           "sessions"
         ],
-        "operationId": "replaySession",
+        "operationId": "terminateSession",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Cookie with access_token",
+            "name": "Cookie",
+            "in": "header",
+            "required": true
+          },
+          {
+            "type": "string",
+            "description": "the reason shown to the user of the session",
+            "name": "reason",
+            "in": "query"
+          }
+        ],
         "responses": {
-          "200": {
-            "description": "replay the session"
+          "204": {
+            "description": "the session has been terminated"
+          },
+          "404": {
+            "description": "the session is not active",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          },
+          "409": {
+            "description": "the session can't be terminated by itself, such as an instance of a fan-out session",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
           }
         }
       },
@@ -316,29 +554,205 @@ func init() {
         }
       ]
     },
-    "/attach": {
+    "/api/sessions/{session_id}/replay": {
       "get": {
         "tags": [
-          "container"
+          "sessions"
         ],
-        "operationId": "attachContainer",
+        "operationId": "replaySession",
         "responses": {
           "200": {
-            "description": "attach to container's stdout/stderr"
+            "description": "replay the session"
           }
         }
-      }
+      },
+      "parameters": [
+        {
+          "type": "integer",
+          "format": "int64",
+          "name": "session_id",
+          "in": "path",
+          "required": true
+        }
+      ]
     },
-    "/enter": {
+    "/api/sessions/{session_id}/screen": {
       "get": {
         "tags": [
-          "container"
+          "sessions"
+        ],
+        "operationId": "getSessionScreen",
+        "parameters": [
+          {
+            "type": "integer",
+            "format": "int64",
+            "description": "the offset in the recording(unit: millisecond), the end of the recording if it is omitted",
+            "name": "offset",
+            "in": "query"
+          },
+          {
+            "maximum": 1000,
+            "type": "integer",
+            "format": "int64",
+            "default": 80,
+            "description": "the width of the terminal which the session is replayed in",
+            "name": "width",
+            "in": "query"
+          },
+          {
+            "maximum": 500,
+            "type": "integer",
+            "format": "int64",
+            "default": 24,
+            "description": "the height of the terminal which the session is replayed in",
+            "name": "height",
+            "in": "query"
+          }
         ],
-        "operationId": "enterContainer",
         "responses": {
           "200": {
-            "description": "enter to the container"
-          }
+            "description": "the screen of the session at the offset, emulated by entry",
+            "schema": {
+              "$ref": "#/definitions/screen"
+            }
+          },
+          "404": {
+            "description": "the recording of the session is not found",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      },
+      "parameters": [
+        {
+          "type": "integer",
+          "format": "int64",
+          "name": "session_id",
+          "in": "path",
+          "required": true
+        }
+      ]
+    },
+    "/api/sessions/{session_id}/terminate": {
+      "post": {
+        "tags": [
+          "sessions"
+        ],
+        "operationId": "terminateSessionByPost",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Cookie with access_token",
+            "name": "Cookie",
+            "in": "header",
+            "required": true
+          },
+          {
+            "type": "string",
+            "description": "the reason shown to the user of the session",
+            "name": "reason",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "204": {
+            "description": "the session has been terminated"
+          },
+          "404": {
+            "description": "the session is not active",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          },
+          "409": {
+            "description": "the session can't be terminated by itself, such as an instance of a fan-out session",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      },
+      "parameters": [
+        {
+          "type": "integer",
+          "format": "int64",
+          "name": "session_id",
+          "in": "path",
+          "required": true
+        }
+      ]
+    },
+    "/api/sessions/{session_id}/watch": {
+      "get": {
+        "tags": [
+          "sessions"
+        ],
+        "operationId": "watchSession",
+        "responses": {
+          "200": {
+            "description": "watch the active session in real time"
+          }
+        }
+      },
+      "parameters": [
+        {
+          "type": "integer",
+          "format": "int64",
+          "name": "session_id",
+          "in": "path",
+          "required": true
+        }
+      ]
+    },
+    "/attach": {
+      "get": {
+        "tags": [
+          "container"
+        ],
+        "operationId": "attachContainer",
+        "responses": {
+          "200": {
+            "description": "attach to container's stdout/stderr"
+          }
+        }
+      }
+    },
+    "/enter": {
+      "get": {
+        "tags": [
+          "container"
+        ],
+        "operationId": "enterContainer",
+        "responses": {
+          "200": {
+            "description": "enter to the container"
+          }
+        }
+      }
+    },
+    "/forward": {
+      "get": {
+        "tags": [
+          "container"
+        ],
+        "operationId": "forwardPort",
+        "responses": {
+          "200": {
+            "description": "forward TCP connections to a port inside the container"
+          }
         }
       }
     }
@@ -350,6 +764,10 @@ func init() {
         "app_name": {
           "type": "string"
         },
+        "captured": {
+          "description": "Whether the command is captured by the shell hook rather than rebuilt from the keystrokes",
+          "type": "boolean"
+        },
         "command_id": {
           "type": "integer",
           "format": "int64",
@@ -363,9 +781,22 @@ func init() {
           "type": "integer",
           "format": "int64"
         },
+        "duration": {
+          "description": "How long the command runs(unit: millisecond), only known if the command is captured",
+          "type": "integer",
+          "format": "int64"
+        },
+        "exit_code": {
+          "description": "-1 if the command has not exited, only known if the command is captured",
+          "type": "integer"
+        },
         "instance_no": {
           "type": "string"
         },
+        "pasted": {
+          "description": "Whether the command is pasted rather than typed, only known if the command is rebuilt from the keystrokes",
+          "type": "boolean"
+        },
         "proc_name": {
           "type": "string"
         },
@@ -376,6 +807,10 @@ func init() {
         },
         "user": {
           "type": "string"
+        },
+        "work_dir": {
+          "description": "The work directory where the command runs, only known if the command is captured",
+          "type": "string"
         }
       }
     },
@@ -425,15 +860,116 @@ func init() {
         }
       }
     },
+    "file_transfer": {
+      "type": "object",
+      "properties": {
+        "app_name": {
+          "type": "string"
+        },
+        "checksum": {
+          "description": "hex encoded sha256 of the file",
+          "type": "string"
+        },
+        "created_at": {
+          "description": "Unix timestamp(unit: second)",
+          "type": "integer",
+          "format": "int64"
+        },
+        "direction": {
+          "description": "upload or download",
+          "type": "string"
+        },
+        "error": {
+          "type": "string"
+        },
+        "file_transfer_id": {
+          "type": "integer",
+          "format": "int64",
+          "readOnly": true
+        },
+        "instance_no": {
+          "type": "string"
+        },
+        "path": {
+          "description": "the file path in the container",
+          "type": "string"
+        },
+        "proc_name": {
+          "type": "string"
+        },
+        "session_id": {
+          "type": "integer",
+          "format": "int64",
+          "readOnly": true
+        },
+        "size": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "status": {
+          "description": "succeeded or failed",
+          "type": "string"
+        },
+        "user": {
+          "type": "string"
+        }
+      }
+    },
+    "screen": {
+      "type": "object",
+      "properties": {
+        "content": {
+          "description": "The text of the screen, the lines are joined by \\n",
+          "type": "string"
+        },
+        "cursor_col": {
+          "description": "The column of the cursor, starting from 0",
+          "type": "integer"
+        },
+        "cursor_row": {
+          "description": "The row of the cursor, starting from 0",
+          "type": "integer"
+        },
+        "height": {
+          "type": "integer"
+        },
+        "offset": {
+          "description": "The offset in the recording(unit: millisecond)",
+          "type": "integer",
+          "format": "int64"
+        },
+        "width": {
+          "type": "integer"
+        }
+      }
+    },
     "session": {
       "type": "object",
       "properties": {
         "app_name": {
           "type": "string"
         },
+        "bytes_in": {
+          "description": "bytes sent to the container, only for forward sessions",
+          "type": "integer",
+          "format": "int64"
+        },
+        "bytes_out": {
+          "description": "bytes received from the container, only for forward sessions",
+          "type": "integer",
+          "format": "int64"
+        },
+        "cleanup_status": {
+          "description": "whether the shell has been cleaned up when the session ended, succeeded, failed, or unknown if no process of the session is found",
+          "type": "string"
+        },
         "container_id": {
           "type": "string"
         },
+        "container_running": {
+          "description": "whether the container was still running when the session ended",
+          "type": "boolean"
+        },
         "created_at": {
           "description": "Unix timestamp(unit: second)",
           "type": "integer",
@@ -444,26 +980,104 @@ func init() {
           "type": "integer",
           "format": "int64"
         },
+        "exit_code": {
+          "description": "the exit code of the shell or the command, -1 if it is unknown",
+          "type": "integer",
+          "format": "int64"
+        },
+        "exit_reason": {
+          "description": "why the session ended, exited, error, terminated, disconnected, canceled, idle_timeout or max_duration",
+          "type": "string"
+        },
         "instance_no": {
           "type": "string"
         },
         "node_ip": {
           "type": "string"
         },
+        "parent_id": {
+          "description": "the fan-out session which the exec session belongs to, 0 if there is none",
+          "type": "integer",
+          "format": "int64"
+        },
         "proc_name": {
           "type": "string"
         },
+        "secret_entered": {
+          "description": "whether a secret such as a password was typed with echo disabled, which is not recorded as a command",
+          "type": "boolean"
+        },
         "session_id": {
           "type": "integer",
           "format": "int64",
           "readOnly": true
         },
+        "shell": {
+          "description": "the shell which is chosen for enter sessions",
+          "type": "string"
+        },
         "source_ip": {
           "type": "string"
         },
         "status": {
           "type": "string"
         },
+        "target_port": {
+          "description": "the port inside the container, only for forward sessions",
+          "type": "integer",
+          "format": "int64"
+        },
+        "terminated_by": {
+          "description": "the entry owner who terminated the session",
+          "type": "string"
+        },
+        "type": {
+          "description": "enter, forward, exec, attach or fanout",
+          "type": "string"
+        },
+        "user": {
+          "type": "string"
+        }
+      }
+    },
+    "session_event": {
+      "type": "object",
+      "properties": {
+        "app_name": {
+          "type": "string"
+        },
+        "content": {
+          "description": "the detail of the event, such as the signal name",
+          "type": "string"
+        },
+        "created_at": {
+          "description": "Unix timestamp(unit: second)",
+          "type": "integer",
+          "format": "int64"
+        },
+        "error": {
+          "type": "string"
+        },
+        "event_id": {
+          "type": "integer",
+          "format": "int64",
+          "readOnly": true
+        },
+        "instance_no": {
+          "type": "string"
+        },
+        "proc_name": {
+          "type": "string"
+        },
+        "session_id": {
+          "type": "integer",
+          "format": "int64",
+          "readOnly": true
+        },
+        "type": {
+          "description": "the type of the event, such as signal",
+          "type": "string"
+        },
         "user": {
           "type": "string"
         }
@@ -627,15 +1241,115 @@ func init() {
         }
       }
     },
-    "/api/logout": {
+    "/api/exec": {
       "get": {
         "tags": [
-          "auth"
+          "container"
         ],
-        "operationId": "logout",
+        "operationId": "execCommand",
         "responses": {
           "200": {
-            "description": "logout",
+            "description": "run a command in the container without tty"
+          }
+        }
+      }
+    },
+    "/api/fanout_exec": {
+      "get": {
+        "tags": [
+          "container"
+        ],
+        "operationId": "fanoutExec",
+        "responses": {
+          "200": {
+            "description": "run a command on the selected instances of the proc without tty"
+          }
+        }
+      }
+    },
+    "/api/file_transfers": {
+      "get": {
+        "tags": [
+          "file_transfers"
+        ],
+        "operationId": "listFileTransfers",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Cookie with access_token",
+            "name": "Cookie",
+            "in": "header",
+            "required": true
+          },
+          {
+            "type": "integer",
+            "format": "int64",
+            "default": 0,
+            "description": "Unix timestamp(unit: second)",
+            "name": "since",
+            "in": "query"
+          },
+          {
+            "type": "integer",
+            "format": "int64",
+            "default": 20,
+            "name": "limit",
+            "in": "query"
+          },
+          {
+            "type": "integer",
+            "format": "int64",
+            "default": 0,
+            "name": "offset",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "MySQL LIKE pattern match",
+            "name": "user",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "MySQL LIKE pattern match",
+            "name": "app_name",
+            "in": "query"
+          },
+          {
+            "type": "integer",
+            "format": "int64",
+            "name": "session_id",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "list the file transfers",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/file_transfer"
+              }
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/api/logout": {
+      "get": {
+        "tags": [
+          "auth"
+        ],
+        "operationId": "logout",
+        "responses": {
+          "200": {
+            "description": "logout",
             "headers": {
               "Set-Cookie": {
                 "type": "string",
@@ -652,91 +1366,383 @@ func init() {
         }
       }
     },
-    "/api/ping": {
+    "/api/ping": {
+      "get": {
+        "tags": [
+          "ping"
+        ],
+        "operationId": "ping",
+        "responses": {
+          "200": {
+            "description": "this server is healthy",
+            "schema": {
+              "type": "string"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/api/proc_logs": {
+      "get": {
+        "tags": [
+          "container"
+        ],
+        "operationId": "streamProcLogs",
+        "responses": {
+          "200": {
+            "description": "stream the logs of all instances of the proc"
+          }
+        }
+      }
+    },
+    "/api/session_events": {
+      "get": {
+        "tags": [
+          "session_events"
+        ],
+        "operationId": "listSessionEvents",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Cookie with access_token",
+            "name": "Cookie",
+            "in": "header",
+            "required": true
+          },
+          {
+            "type": "integer",
+            "format": "int64",
+            "default": 0,
+            "description": "Unix timestamp(unit: second)",
+            "name": "since",
+            "in": "query"
+          },
+          {
+            "type": "integer",
+            "format": "int64",
+            "default": 20,
+            "name": "limit",
+            "in": "query"
+          },
+          {
+            "type": "integer",
+            "format": "int64",
+            "default": 0,
+            "name": "offset",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "MySQL LIKE pattern match",
+            "name": "user",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "MySQL LIKE pattern match",
+            "name": "app_name",
+            "in": "query"
+          },
+          {
+            "type": "integer",
+            "format": "int64",
+            "name": "session_id",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "name": "type",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "list the audit events of sessions",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/session_event"
+              }
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/api/sessions": {
+      "get": {
+        "tags": [
+          "sessions"
+        ],
+        "operationId": "listSessions",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Cookie with access_token",
+            "name": "Cookie",
+            "in": "header",
+            "required": true
+          },
+          {
+            "type": "integer",
+            "format": "int64",
+            "name": "session_id",
+            "in": "query"
+          },
+          {
+            "type": "integer",
+            "format": "int64",
+            "default": 0,
+            "description": "Unix timestamp(unit: second)",
+            "name": "since",
+            "in": "query"
+          },
+          {
+            "type": "integer",
+            "format": "int64",
+            "default": 20,
+            "name": "limit",
+            "in": "query"
+          },
+          {
+            "type": "integer",
+            "format": "int64",
+            "default": 0,
+            "name": "offset",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "MySQL LIKE pattern match",
+            "name": "user",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "MySQL LIKE pattern match",
+            "name": "app_name",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "enter, forward, exec, attach or fanout",
+            "name": "type",
+            "in": "query"
+          },
+          {
+            "type": "integer",
+            "format": "int64",
+            "description": "list the exec sessions of the fan-out session",
+            "name": "parent_id",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "list the sessions",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/session"
+              }
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/api/sessions/{session_id}": {
+      "delete": {
+        "tags": [
+          "sessions"
+        ],
+        "operationId": "terminateSession",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Cookie with access_token",
+            "name": "Cookie",
+            "in": "header",
+            "required": true
+          },
+          {
+            "type": "string",
+            "description": "the reason shown to the user of the session",
+            "name": "reason",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "204": {
+            "description": "the session has been terminated"
+          },
+          "404": {
+            "description": "the session is not active",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          },
+          "409": {
+            "description": "the session can't be terminated by itself, such as an instance of a fan-out session",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      },
+      "parameters": [
+        {
+          "type": "integer",
+          "format": "int64",
+          "name": "session_id",
+          "in": "path",
+          "required": true
+        }
+      ]
+    },
+    "/api/sessions/{session_id}/replay": {
       "get": {
         "tags": [
-          "ping"
+          "sessions"
         ],
-        "operationId": "ping",
+        "operationId": "replaySession",
         "responses": {
           "200": {
-            "description": "this server is healthy",
-            "schema": {
-              "type": "string"
-            }
-          },
-          "default": {
-            "description": "generic error response",
-            "schema": {
-              "$ref": "#/definitions/error"
-            }
+            "description": "replay the session"
           }
         }
-      }
+      },
+      "parameters": [
+        {
+          "type": "integer",
+          "format": "int64",
+          "name": "session_id",
+          "in": "path",
+          "required": true
+        }
+      ]
     },
-    "/api/sessions": {
+    "/api/sessions/{session_id}/screen": {
       "get": {
         "tags": [
           "sessions"
         ],
-        "operationId": "listSessions",
+        "operationId": "getSessionScreen",
         "parameters": [
-          {
-            "type": "string",
-            "description": "Cookie with access_token",
-            "name": "Cookie",
-            "in": "header",
-            "required": true
-          },
           {
             "type": "integer",
             "format": "int64",
-            "name": "session_id",
+            "description": "the offset in the recording(unit: millisecond), the end of the recording if it is omitted",
+            "name": "offset",
             "in": "query"
           },
           {
+            "maximum": 1000,
             "type": "integer",
             "format": "int64",
-            "default": 0,
-            "description": "Unix timestamp(unit: second)",
-            "name": "since",
+            "default": 80,
+            "description": "the width of the terminal which the session is replayed in",
+            "name": "width",
             "in": "query"
           },
           {
+            "maximum": 500,
             "type": "integer",
             "format": "int64",
-            "default": 20,
-            "name": "limit",
+            "default": 24,
+            "description": "the height of the terminal which the session is replayed in",
+            "name": "height",
             "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "the screen of the session at the offset, emulated by entry",
+            "schema": {
+              "$ref": "#/definitions/screen"
+            }
           },
-          {
-            "type": "integer",
-            "format": "int64",
-            "default": 0,
-            "name": "offset",
-            "in": "query"
+          "404": {
+            "description": "the recording of the session is not found",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
           },
+          "default": {
+            "description": "generic error response",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      },
+      "parameters": [
+        {
+          "type": "integer",
+          "format": "int64",
+          "name": "session_id",
+          "in": "path",
+          "required": true
+        }
+      ]
+    },
+    "/api/sessions/{session_id}/terminate": {
+      "post": {
+        "tags": [
+          "sessions"
+        ],
+        "operationId": "terminateSessionByPost",
+        "parameters": [
           {
             "type": "string",
-            "description": "MySQL LIKE pattern match",
-            "name": "user",
-            "in": "query"
+            "description": "Cookie with access_token",
+            "name": "Cookie",
+            "in": "header",
+            "required": true
           },
           {
             "type": "string",
-            "description": "MySQL LIKE pattern match",
-            "name": "app_name",
+            "description": "the reason shown to the user of the session",
+            "name": "reason",
             "in": "query"
           }
         ],
         "responses": {
-          "200": {
-            "description": "list the sessions",
+          "204": {
+            "description": "the session has been terminated"
+          },
+          "404": {
+            "description": "the session is not active",
             "schema": {
-              "type": "array",
-              "items": {
-                "$ref": "#/definitions/session"
-              }
+              "$ref": "#/definitions/error"
+            }
+          },
+          "409": {
+            "description": "the session can't be terminated by itself, such as an instance of a fan-out session",
+            "schema": {
+              "$ref": "#/definitions/error"
             }
           },
           "default": {
@@ -746,17 +1752,26 @@ func init() {
             }
           }
         }
-      }
+      },
+      "parameters": [
+        {
+          "type": "integer",
+          "format": "int64",
+          "name": "session_id",
+          "in": "path",
+          "required": true
+        }
+      ]
     },
-    "/api/sessions/{session_id}/replay": {
+    "/api/sessions/{session_id}/watch": {
       "get": {
         "tags": [
           "sessions"
         ],
-        "operationId": "replaySession",
+        "operationId": "watchSession",
         "responses": {
           "200": {
-            "description": "replay the session"
+            "description": "watch the active session in real time"
           }
         }
       },
@@ -795,6 +1810,19 @@ func init() {
           }
         }
       }
+    },
+    "/forward": {
+      "get": {
+        "tags": [
+          "container"
+        ],
+        "operationId": "forwardPort",
+        "responses": {
+          "200": {
+            "description": "forward TCP connections to a port inside the container"
+          }
+        }
+      }
     }
   },
   "definitions": {
@@ -804,6 +1832,10 @@ func init() {
         "app_name": {
           "type": "string"
         },
+        "captured": {
+          "description": "Whether the command is captured by the shell hook rather than rebuilt from the keystrokes",
+          "type": "boolean"
+        },
         "command_id": {
           "type": "integer",
           "format": "int64",
@@ -817,9 +1849,22 @@ func init() {
           "type": "integer",
           "format": "int64"
         },
+        "duration": {
+          "description": "How long the command runs(unit: millisecond), only known if the command is captured",
+          "type": "integer",
+          "format": "int64"
+        },
+        "exit_code": {
+          "description": "-1 if the command has not exited, only known if the command is captured",
+          "type": "integer"
+        },
         "instance_no": {
           "type": "string"
         },
+        "pasted": {
+          "description": "Whether the command is pasted rather than typed, only known if the command is rebuilt from the keystrokes",
+          "type": "boolean"
+        },
         "proc_name": {
           "type": "string"
         },
@@ -830,6 +1875,10 @@ func init() {
         },
         "user": {
           "type": "string"
+        },
+        "work_dir": {
+          "description": "The work directory where the command runs, only known if the command is captured",
+          "type": "string"
         }
       }
     },
@@ -883,15 +1932,116 @@ func init() {
         }
       }
     },
+    "file_transfer": {
+      "type": "object",
+      "properties": {
+        "app_name": {
+          "type": "string"
+        },
+        "checksum": {
+          "description": "hex encoded sha256 of the file",
+          "type": "string"
+        },
+        "created_at": {
+          "description": "Unix timestamp(unit: second)",
+          "type": "integer",
+          "format": "int64"
+        },
+        "direction": {
+          "description": "upload or download",
+          "type": "string"
+        },
+        "error": {
+          "type": "string"
+        },
+        "file_transfer_id": {
+          "type": "integer",
+          "format": "int64",
+          "readOnly": true
+        },
+        "instance_no": {
+          "type": "string"
+        },
+        "path": {
+          "description": "the file path in the container",
+          "type": "string"
+        },
+        "proc_name": {
+          "type": "string"
+        },
+        "session_id": {
+          "type": "integer",
+          "format": "int64",
+          "readOnly": true
+        },
+        "size": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "status": {
+          "description": "succeeded or failed",
+          "type": "string"
+        },
+        "user": {
+          "type": "string"
+        }
+      }
+    },
+    "screen": {
+      "type": "object",
+      "properties": {
+        "content": {
+          "description": "The text of the screen, the lines are joined by \\n",
+          "type": "string"
+        },
+        "cursor_col": {
+          "description": "The column of the cursor, starting from 0",
+          "type": "integer"
+        },
+        "cursor_row": {
+          "description": "The row of the cursor, starting from 0",
+          "type": "integer"
+        },
+        "height": {
+          "type": "integer"
+        },
+        "offset": {
+          "description": "The offset in the recording(unit: millisecond)",
+          "type": "integer",
+          "format": "int64"
+        },
+        "width": {
+          "type": "integer"
+        }
+      }
+    },
     "session": {
       "type": "object",
       "properties": {
         "app_name": {
           "type": "string"
         },
+        "bytes_in": {
+          "description": "bytes sent to the container, only for forward sessions",
+          "type": "integer",
+          "format": "int64"
+        },
+        "bytes_out": {
+          "description": "bytes received from the container, only for forward sessions",
+          "type": "integer",
+          "format": "int64"
+        },
+        "cleanup_status": {
+          "description": "whether the shell has been cleaned up when the session ended, succeeded, failed, or unknown if no process of the session is found",
+          "type": "string"
+        },
         "container_id": {
           "type": "string"
         },
+        "container_running": {
+          "description": "whether the container was still running when the session ended",
+          "type": "boolean"
+        },
         "created_at": {
           "description": "Unix timestamp(unit: second)",
           "type": "integer",
@@ -902,26 +2052,104 @@ func init() {
           "type": "integer",
           "format": "int64"
         },
+        "exit_code": {
+          "description": "the exit code of the shell or the command, -1 if it is unknown",
+          "type": "integer",
+          "format": "int64"
+        },
+        "exit_reason": {
+          "description": "why the session ended, exited, error, terminated, disconnected, canceled, idle_timeout or max_duration",
+          "type": "string"
+        },
         "instance_no": {
           "type": "string"
         },
         "node_ip": {
           "type": "string"
         },
+        "parent_id": {
+          "description": "the fan-out session which the exec session belongs to, 0 if there is none",
+          "type": "integer",
+          "format": "int64"
+        },
         "proc_name": {
           "type": "string"
         },
+        "secret_entered": {
+          "description": "whether a secret such as a password was typed with echo disabled, which is not recorded as a command",
+          "type": "boolean"
+        },
         "session_id": {
           "type": "integer",
           "format": "int64",
           "readOnly": true
         },
+        "shell": {
+          "description": "the shell which is chosen for enter sessions",
+          "type": "string"
+        },
         "source_ip": {
           "type": "string"
         },
         "status": {
           "type": "string"
         },
+        "target_port": {
+          "description": "the port inside the container, only for forward sessions",
+          "type": "integer",
+          "format": "int64"
+        },
+        "terminated_by": {
+          "description": "the entry owner who terminated the session",
+          "type": "string"
+        },
+        "type": {
+          "description": "enter, forward, exec, attach or fanout",
+          "type": "string"
+        },
+        "user": {
+          "type": "string"
+        }
+      }
+    },
+    "session_event": {
+      "type": "object",
+      "properties": {
+        "app_name": {
+          "type": "string"
+        },
+        "content": {
+          "description": "the detail of the event, such as the signal name",
+          "type": "string"
+        },
+        "created_at": {
+          "description": "Unix timestamp(unit: second)",
+          "type": "integer",
+          "format": "int64"
+        },
+        "error": {
+          "type": "string"
+        },
+        "event_id": {
+          "type": "integer",
+          "format": "int64",
+          "readOnly": true
+        },
+        "instance_no": {
+          "type": "string"
+        },
+        "proc_name": {
+          "type": "string"
+        },
+        "session_id": {
+          "type": "integer",
+          "format": "int64",
+          "readOnly": true
+        },
+        "type": {
+          "description": "the type of the event, such as signal",
+          "type": "string"
+        },
         "user": {
           "type": "string"
         }
@@ -23,7 +23,9 @@ import (
 	"github.com/laincloud/entry/server/gen/restapi/operations/commands"
 	"github.com/laincloud/entry/server/gen/restapi/operations/config"
 	"github.com/laincloud/entry/server/gen/restapi/operations/container"
+	"github.com/laincloud/entry/server/gen/restapi/operations/file_transfers"
 	"github.com/laincloud/entry/server/gen/restapi/operations/ping"
+	"github.com/laincloud/entry/server/gen/restapi/operations/session_events"
 	"github.com/laincloud/entry/server/gen/restapi/operations/sessions"
 )
 
@@ -53,12 +55,30 @@ func NewEntryAPI(spec *loads.Document) *EntryAPI {
 		ContainerEnterContainerHandler: container.EnterContainerHandlerFunc(func(params container.EnterContainerParams) middleware.Responder {
 			return middleware.NotImplemented("operation ContainerEnterContainer has not yet been implemented")
 		}),
+		ContainerExecCommandHandler: container.ExecCommandHandlerFunc(func(params container.ExecCommandParams) middleware.Responder {
+			return middleware.NotImplemented("operation ContainerExecCommand has not yet been implemented")
+		}),
+		ContainerFanoutExecHandler: container.FanoutExecHandlerFunc(func(params container.FanoutExecParams) middleware.Responder {
+			return middleware.NotImplemented("operation ContainerFanoutExec has not yet been implemented")
+		}),
+		ContainerForwardPortHandler: container.ForwardPortHandlerFunc(func(params container.ForwardPortParams) middleware.Responder {
+			return middleware.NotImplemented("operation ContainerForwardPort has not yet been implemented")
+		}),
 		ConfigGetConfigHandler: config.GetConfigHandlerFunc(func(params config.GetConfigParams) middleware.Responder {
 			return middleware.NotImplemented("operation ConfigGetConfig has not yet been implemented")
 		}),
+		SessionsGetSessionScreenHandler: sessions.GetSessionScreenHandlerFunc(func(params sessions.GetSessionScreenParams) middleware.Responder {
+			return middleware.NotImplemented("operation SessionsGetSessionScreen has not yet been implemented")
+		}),
 		CommandsListCommandsHandler: commands.ListCommandsHandlerFunc(func(params commands.ListCommandsParams) middleware.Responder {
 			return middleware.NotImplemented("operation CommandsListCommands has not yet been implemented")
 		}),
+		FileTransfersListFileTransfersHandler: file_transfers.ListFileTransfersHandlerFunc(func(params file_transfers.ListFileTransfersParams) middleware.Responder {
+			return middleware.NotImplemented("operation FileTransfersListFileTransfers has not yet been implemented")
+		}),
+		SessionEventsListSessionEventsHandler: session_events.ListSessionEventsHandlerFunc(func(params session_events.ListSessionEventsParams) middleware.Responder {
+			return middleware.NotImplemented("operation SessionEventsListSessionEvents has not yet been implemented")
+		}),
 		SessionsListSessionsHandler: sessions.ListSessionsHandlerFunc(func(params sessions.ListSessionsParams) middleware.Responder {
 			return middleware.NotImplemented("operation SessionsListSessions has not yet been implemented")
 		}),
@@ -71,6 +91,18 @@ func NewEntryAPI(spec *loads.Document) *EntryAPI {
 		SessionsReplaySessionHandler: sessions.ReplaySessionHandlerFunc(func(params sessions.ReplaySessionParams) middleware.Responder {
 			return middleware.NotImplemented("operation SessionsReplaySession has not yet been implemented")
 		}),
+		ContainerStreamProcLogsHandler: container.StreamProcLogsHandlerFunc(func(params container.StreamProcLogsParams) middleware.Responder {
+			return middleware.NotImplemented("operation ContainerStreamProcLogs has not yet been implemented")
+		}),
+		SessionsTerminateSessionHandler: sessions.TerminateSessionHandlerFunc(func(params sessions.TerminateSessionParams) middleware.Responder {
+			return middleware.NotImplemented("operation SessionsTerminateSession has not yet been implemented")
+		}),
+		SessionsTerminateSessionByPostHandler: sessions.TerminateSessionByPostHandlerFunc(func(params sessions.TerminateSessionByPostParams) middleware.Responder {
+			return middleware.NotImplemented("operation SessionsTerminateSessionByPost has not yet been implemented")
+		}),
+		SessionsWatchSessionHandler: sessions.WatchSessionHandlerFunc(func(params sessions.WatchSessionParams) middleware.Responder {
+			return middleware.NotImplemented("operation SessionsWatchSession has not yet been implemented")
+		}),
 	}
 }
 
@@ -108,10 +140,22 @@ type EntryAPI struct {
 	AuthAuthorizeHandler auth.AuthorizeHandler
 	// ContainerEnterContainerHandler sets the operation handler for the enter container operation
 	ContainerEnterContainerHandler container.EnterContainerHandler
+	// ContainerExecCommandHandler sets the operation handler for the exec command operation
+	ContainerExecCommandHandler container.ExecCommandHandler
+	// ContainerFanoutExecHandler sets the operation handler for the fanout exec operation
+	ContainerFanoutExecHandler container.FanoutExecHandler
+	// ContainerForwardPortHandler sets the operation handler for the forward port operation
+	ContainerForwardPortHandler container.ForwardPortHandler
 	// ConfigGetConfigHandler sets the operation handler for the get config operation
 	ConfigGetConfigHandler config.GetConfigHandler
+	// SessionsGetSessionScreenHandler sets the operation handler for the get session screen operation
+	SessionsGetSessionScreenHandler sessions.GetSessionScreenHandler
 	// CommandsListCommandsHandler sets the operation handler for the list commands operation
 	CommandsListCommandsHandler commands.ListCommandsHandler
+	// FileTransfersListFileTransfersHandler sets the operation handler for the list file transfers operation
+	FileTransfersListFileTransfersHandler file_transfers.ListFileTransfersHandler
+	// SessionEventsListSessionEventsHandler sets the operation handler for the list session events operation
+	SessionEventsListSessionEventsHandler session_events.ListSessionEventsHandler
 	// SessionsListSessionsHandler sets the operation handler for the list sessions operation
 	SessionsListSessionsHandler sessions.ListSessionsHandler
 	// AuthLogoutHandler sets the operation handler for the logout operation
@@ -120,6 +164,14 @@ type EntryAPI struct {
 	PingPingHandler ping.PingHandler
 	// SessionsReplaySessionHandler sets the operation handler for the replay session operation
 	SessionsReplaySessionHandler sessions.ReplaySessionHandler
+	// ContainerStreamProcLogsHandler sets the operation handler for the stream proc logs operation
+	ContainerStreamProcLogsHandler container.StreamProcLogsHandler
+	// SessionsTerminateSessionHandler sets the operation handler for the terminate session operation
+	SessionsTerminateSessionHandler sessions.TerminateSessionHandler
+	// SessionsTerminateSessionByPostHandler sets the operation handler for the terminate session by post operation
+	SessionsTerminateSessionByPostHandler sessions.TerminateSessionByPostHandler
+	// SessionsWatchSessionHandler sets the operation handler for the watch session operation
+	SessionsWatchSessionHandler sessions.WatchSessionHandler
 
 	// ServeError is called when an error is received, there is a default handler
 	// but you can set your own with this
@@ -195,14 +247,38 @@ func (o *EntryAPI) Validate() error {
 		unregistered = append(unregistered, "container.EnterContainerHandler")
 	}
 
+	if o.ContainerExecCommandHandler == nil {
+		unregistered = append(unregistered, "container.ExecCommandHandler")
+	}
+
+	if o.ContainerFanoutExecHandler == nil {
+		unregistered = append(unregistered, "container.FanoutExecHandler")
+	}
+
+	if o.ContainerForwardPortHandler == nil {
+		unregistered = append(unregistered, "container.ForwardPortHandler")
+	}
+
 	if o.ConfigGetConfigHandler == nil {
 		unregistered = append(unregistered, "config.GetConfigHandler")
 	}
 
+	if o.SessionsGetSessionScreenHandler == nil {
+		unregistered = append(unregistered, "sessions.GetSessionScreenHandler")
+	}
+
 	if o.CommandsListCommandsHandler == nil {
 		unregistered = append(unregistered, "commands.ListCommandsHandler")
 	}
 
+	if o.FileTransfersListFileTransfersHandler == nil {
+		unregistered = append(unregistered, "file_transfers.ListFileTransfersHandler")
+	}
+
+	if o.SessionEventsListSessionEventsHandler == nil {
+		unregistered = append(unregistered, "session_events.ListSessionEventsHandler")
+	}
+
 	if o.SessionsListSessionsHandler == nil {
 		unregistered = append(unregistered, "sessions.ListSessionsHandler")
 	}
@@ -219,6 +295,22 @@ func (o *EntryAPI) Validate() error {
 		unregistered = append(unregistered, "sessions.ReplaySessionHandler")
 	}
 
+	if o.ContainerStreamProcLogsHandler == nil {
+		unregistered = append(unregistered, "container.StreamProcLogsHandler")
+	}
+
+	if o.SessionsTerminateSessionHandler == nil {
+		unregistered = append(unregistered, "sessions.TerminateSessionHandler")
+	}
+
+	if o.SessionsTerminateSessionByPostHandler == nil {
+		unregistered = append(unregistered, "sessions.TerminateSessionByPostHandler")
+	}
+
+	if o.SessionsWatchSessionHandler == nil {
+		unregistered = append(unregistered, "sessions.WatchSessionHandler")
+	}
+
 	if len(unregistered) > 0 {
 		return fmt.Errorf("missing registration: %s", strings.Join(unregistered, ", "))
 	}
@@ -332,16 +424,46 @@ func (o *EntryAPI) initHandlerCache() {
 	}
 	o.handlers["GET"]["/enter"] = container.NewEnterContainer(o.context, o.ContainerEnterContainerHandler)
 
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/api/exec"] = container.NewExecCommand(o.context, o.ContainerExecCommandHandler)
+
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/api/fanout_exec"] = container.NewFanoutExec(o.context, o.ContainerFanoutExecHandler)
+
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/forward"] = container.NewForwardPort(o.context, o.ContainerForwardPortHandler)
+
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
 	}
 	o.handlers["GET"]["/api/config"] = config.NewGetConfig(o.context, o.ConfigGetConfigHandler)
 
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/api/sessions/{session_id}/screen"] = sessions.NewGetSessionScreen(o.context, o.SessionsGetSessionScreenHandler)
+
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
 	}
 	o.handlers["GET"]["/api/commands"] = commands.NewListCommands(o.context, o.CommandsListCommandsHandler)
 
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/api/file_transfers"] = file_transfers.NewListFileTransfers(o.context, o.FileTransfersListFileTransfersHandler)
+
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/api/session_events"] = session_events.NewListSessionEvents(o.context, o.SessionEventsListSessionEventsHandler)
+
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
 	}
@@ -362,6 +484,26 @@ func (o *EntryAPI) initHandlerCache() {
 	}
 	o.handlers["GET"]["/api/sessions/{session_id}/replay"] = sessions.NewReplaySession(o.context, o.SessionsReplaySessionHandler)
 
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/api/proc_logs"] = container.NewStreamProcLogs(o.context, o.ContainerStreamProcLogsHandler)
+
+	if o.handlers["DELETE"] == nil {
+		o.handlers["DELETE"] = make(map[string]http.Handler)
+	}
+	o.handlers["DELETE"]["/api/sessions/{session_id}"] = sessions.NewTerminateSession(o.context, o.SessionsTerminateSessionHandler)
+
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/api/sessions/{session_id}/terminate"] = sessions.NewTerminateSessionByPost(o.context, o.SessionsTerminateSessionByPostHandler)
+
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/api/sessions/{session_id}/watch"] = sessions.NewWatchSession(o.context, o.SessionsWatchSessionHandler)
+
 }
 
 // Serve creates a http handler to serve the API over HTTP
@@ -67,6 +67,10 @@ type ListSessionsParams struct {
 	  Default: 0
 	*/
 	Offset *int64
+	/*list the exec sessions of the fan-out session
+	  In: query
+	*/
+	ParentID *int64
 	/*
 	  In: query
 	*/
@@ -76,6 +80,10 @@ type ListSessionsParams struct {
 	  Default: 0
 	*/
 	Since *int64
+	/*enter, forward, exec, attach or fanout
+	  In: query
+	*/
+	Type *string
 	/*MySQL LIKE pattern match
 	  In: query
 	*/
@@ -112,6 +120,11 @@ func (o *ListSessionsParams) BindRequest(r *http.Request, route *middleware.Matc
 		res = append(res, err)
 	}
 
+	qParentID, qhkParentID, _ := qs.GetOK("parent_id")
+	if err := o.bindParentID(qParentID, qhkParentID, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
 	qSessionID, qhkSessionID, _ := qs.GetOK("session_id")
 	if err := o.bindSessionID(qSessionID, qhkSessionID, route.Formats); err != nil {
 		res = append(res, err)
@@ -122,6 +135,11 @@ func (o *ListSessionsParams) BindRequest(r *http.Request, route *middleware.Matc
 		res = append(res, err)
 	}
 
+	qType, qhkType, _ := qs.GetOK("type")
+	if err := o.bindType(qType, qhkType, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
 	qUser, qhkUser, _ := qs.GetOK("user")
 	if err := o.bindUser(qUser, qhkUser, route.Formats); err != nil {
 		res = append(res, err)
@@ -214,6 +232,27 @@ func (o *ListSessionsParams) bindOffset(rawData []string, hasKey bool, formats s
 	return nil
 }
 
+func (o *ListSessionsParams) bindParentID(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	value, err := swag.ConvertInt64(raw)
+	if err != nil {
+		return errors.InvalidType("parent_id", "query", "int64", raw)
+	}
+	o.ParentID = &value
+
+	return nil
+}
+
 func (o *ListSessionsParams) bindSessionID(rawData []string, hasKey bool, formats strfmt.Registry) error {
 	var raw string
 	if len(rawData) > 0 {
@@ -257,6 +296,23 @@ func (o *ListSessionsParams) bindSince(rawData []string, hasKey bool, formats st
 	return nil
 }
 
+func (o *ListSessionsParams) bindType(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	o.Type = &raw
+
+	return nil
+}
+
 func (o *ListSessionsParams) bindUser(rawData []string, hasKey bool, formats strfmt.Registry) error {
 	var raw string
 	if len(rawData) > 0 {
@@ -18,8 +18,10 @@ type ListSessionsURL struct {
 	AppName   *string
 	Limit     *int64
 	Offset    *int64
+	ParentID  *int64
 	SessionID *int64
 	Since     *int64
+	Type      *string
 	User      *string
 
 	_basePath string
@@ -77,6 +79,14 @@ func (o *ListSessionsURL) Build() (*url.URL, error) {
 		qs.Set("offset", offset)
 	}
 
+	var parentID string
+	if o.ParentID != nil {
+		parentID = swag.FormatInt64(*o.ParentID)
+	}
+	if parentID != "" {
+		qs.Set("parent_id", parentID)
+	}
+
 	var sessionID string
 	if o.SessionID != nil {
 		sessionID = swag.FormatInt64(*o.SessionID)
@@ -93,6 +103,14 @@ func (o *ListSessionsURL) Build() (*url.URL, error) {
 		qs.Set("since", since)
 	}
 
+	var typeVar string
+	if o.Type != nil {
+		typeVar = *o.Type
+	}
+	if typeVar != "" {
+		qs.Set("type", typeVar)
+	}
+
 	var user string
 	if o.User != nil {
 		user = *o.User
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"os"
 	"sync"
 	"time"
 
@@ -19,7 +20,12 @@ import (
 	"github.com/laincloud/entry/server/util"
 )
 
-// Attach attach to container to view stdout/stderr of the container
+const (
+	detachMsg = "\033[32m>>> You detached from the container safely.\033[0m"
+)
+
+// Attach attach to container to view stdout/stderr of the container, or the logs of the container with the log options,
+// or to interact with it if the session is interactive
 func Attach(ctx context.Context, conn *websocket.Conn, r *http.Request, g *global.Global) {
 	s, err := models.NewSession(conn, r, g)
 	if err != nil {
@@ -27,6 +33,32 @@ func Attach(ctx context.Context, conn *websocket.Conn, r *http.Request, g *globa
 		return
 	}
 
+	if s.Interactive {
+		attachInteractive(ctx, conn, r, s, g)
+		return
+	}
+
+	msgMarshaller, msgUnmarshaller := util.GetMarshalers(r)
+	writeLock := &sync.Mutex{}
+	logOpts, err := parseLogOptions(r)
+	if err != nil {
+		errMsg := fmt.Sprintf(util.ErrMsgTemplate, fmt.Sprintf("Invalid log options: %s.", err))
+		log.Errorf("parseLogOptions() failed, error: %s, session: %+v.", err, s)
+		util.SendCloseMessage(conn, []byte(errMsg), msgMarshaller, writeLock)
+		return
+	}
+
+	sessionReplay, endSession, err := recordAttachSession(conn, s, msgMarshaller, writeLock, g)
+	if err != nil {
+		return
+	}
+	defer endSession()
+
+	if logOpts != nil {
+		attachLogs(ctx, conn, r, s, logOpts, sessionReplay, g)
+		return
+	}
+
 	stdoutPipeReader, stdoutPipeWriter := io.Pipe()
 	stderrPipeReader, stderrPipeWriter := io.Pipe()
 	wg := &sync.WaitGroup{}
@@ -42,18 +74,18 @@ func Attach(ctx context.Context, conn *websocket.Conn, r *http.Request, g *globa
 		ErrorStream:  stderrPipeWriter,
 	}
 
-	msgMarshaler, msgUnMarshaler := util.GetMarshalers(r)
-	writeLock := &sync.Mutex{}
-	p := pipe.NewPipe(conn, msgMarshaler, s, msgUnMarshaler, wg, writeLock)
-	go p.HandleResponse(message.ResponseMessage_STDOUT, stdoutPipeReader, nil)
-	go p.HandleResponse(message.ResponseMessage_STDERR, stderrPipeReader, nil)
+	p := pipe.NewPipe(conn, msgMarshaller, s, msgUnmarshaller, wg, writeLock)
+	p.Register("")
+	defer p.Unregister()
+	go p.HandleResponse(message.ResponseMessage_STDOUT, stdoutPipeReader, sessionReplay)
+	go p.HandleResponse(message.ResponseMessage_STDERR, stderrPipeReader, sessionReplay)
 
 	stopSignal := make(chan int)
 	go func() {
 		if waiter, err := g.DockerClient.AttachToContainerNonBlocking(opts); err != nil {
 			errMsg := fmt.Sprintf(util.ErrMsgTemplate, "Can't attach your container, try again.")
 			log.Errorf("Attach failed: %s", err.Error())
-			util.SendCloseMessage(conn, []byte(errMsg), msgMarshaler, writeLock)
+			util.SendCloseMessage(conn, []byte(errMsg), msgMarshaller, writeLock)
 		} else {
 			// Check whether the websocket is closed
 			for {
@@ -78,3 +110,125 @@ func Attach(ctx context.Context, conn *websocket.Conn, r *http.Request, g *globa
 	stderrPipeWriter.Close()
 	wg.Wait()
 }
+
+// recordAttachSession persist the attach session and record its output for replay just like Enter,
+// since the output of the container can expose secrets too, the returned function ends the session
+func recordAttachSession(conn *websocket.Conn, s *models.Session, msgMarshaller util.Marshaler, writeLock *sync.Mutex, g *global.Global) (*pipe.SessionReplay, func(), error) {
+	s.Type = models.SessionTypeAttach
+	if err := s.Create(g); err != nil {
+		errMsg := fmt.Sprintf(util.ErrMsgTemplate, "Can't record the session, please contact the entry owners.")
+		log.Errorf("s.Create() failed, error: %s, session: %+v.", err, s)
+		util.SendCloseMessage(conn, []byte(errMsg), msgMarshaller, writeLock)
+		return nil, nil, err
+	}
+	endSession := func() {
+		s.Update(models.Session{
+			Status:  models.SessionStatusInactive,
+			EndedAt: time.Now(),
+		}, g)
+	}
+	sendSessionInfo(conn, s, msgMarshaller, writeLock, g)
+
+	if err := os.MkdirAll(s.DataPath(), 0700); err != nil {
+		log.Errorf("os.MkdirAll(%s) failed, error: %s.", s.DataPath(), err)
+		endSession()
+		return nil, nil, err
+	}
+
+	sessionReplay, err := pipe.NewSessionReplay(*s)
+	if err != nil {
+		log.Errorf("pipe.NewSessionReplay(%v) failed, error: %s.", s, err)
+		endSession()
+		return nil, nil, err
+	}
+
+	return sessionReplay, func() {
+		sessionReplay.Close()
+		endSession()
+	}, nil
+}
+
+// attachInteractive forward the input of the client to the stdin of the container, the session is recorded just like Enter
+func attachInteractive(ctx context.Context, conn *websocket.Conn, r *http.Request, s *models.Session, g *global.Global) {
+	msgMarshaller, msgUnmarshaller := util.GetMarshalers(r)
+	writeLock := &sync.Mutex{}
+	container, err := g.DockerClient.InspectContainer(s.ContainerID)
+	if err != nil {
+		errMsg := fmt.Sprintf(util.ErrMsgTemplate, "Can't attach your container, try again.")
+		log.Errorf("g.DockerClient.InspectContainer() failed, error: %s, session: %+v.", err, s)
+		util.SendCloseMessage(conn, []byte(errMsg), msgMarshaller, writeLock)
+		return
+	}
+
+	if !container.Config.OpenStdin {
+		errMsg := fmt.Sprintf(util.ErrMsgTemplate, "The stdin of your container is not open, it should be started with -i.")
+		log.Errorf("Stdin of the container is not open, session: %+v.", s)
+		util.SendCloseMessage(conn, []byte(errMsg), msgMarshaller, writeLock)
+		return
+	}
+
+	sessionReplay, endSession, err := recordAttachSession(conn, s, msgMarshaller, writeLock, g)
+	if err != nil {
+		return
+	}
+	defer endSession()
+
+	stdinPipeReader, stdinPipeWriter := io.Pipe()
+	stdoutPipeReader, stdoutPipeWriter := io.Pipe()
+	stderrPipeReader, stderrPipeWriter := io.Pipe()
+	opts := docker.AttachToContainerOptions{
+		Container:    s.ContainerID,
+		InputStream:  stdinPipeReader,
+		OutputStream: stdoutPipeWriter,
+		ErrorStream:  stderrPipeWriter,
+		Stdin:        true,
+		Stdout:       true,
+		Stderr:       true,
+		Stream:       true,
+		RawTerminal:  container.Config.Tty,
+	}
+	waiter, err := g.DockerClient.AttachToContainerNonBlocking(opts)
+	if err != nil {
+		errMsg := fmt.Sprintf(util.ErrMsgTemplate, "Can't attach your container, try again.")
+		log.Errorf("g.DockerClient.AttachToContainerNonBlocking() failed, error: %s, session: %+v.", err, s)
+		util.SendCloseMessage(conn, []byte(errMsg), msgMarshaller, writeLock)
+		return
+	}
+
+	wg := &sync.WaitGroup{}
+	wg.Add(3)
+	p := pipe.NewPipe(conn, msgMarshaller, s, msgUnmarshaller, wg, writeLock)
+	p.Register("")
+	defer p.Unregister()
+	disconnected := make(chan int)
+	go func() {
+		p.HandleAttachRequest(stdinPipeWriter, g)
+		close(disconnected)
+	}()
+	go p.HandleResponse(message.ResponseMessage_STDOUT, stdoutPipeReader, sessionReplay)
+	go p.HandleResponse(message.ResponseMessage_STDERR, stderrPipeReader, sessionReplay)
+
+	stopSignal := make(chan int)
+	go func() {
+		if err1 := waiter.Wait(); err1 != nil {
+			log.Errorf("waiter.Wait() failed, error: %s, session: %+v.", err1, s)
+		}
+		close(stopSignal)
+	}()
+
+	select {
+	case <-ctx.Done():
+		log.Infof("Attaching to %s canceled, session: %+v.", s.ContainerID, s)
+	case <-disconnected:
+		log.Infof("Websocket to %s disconnected, session: %+v.", s.ContainerID, s)
+	case <-stopSignal:
+		log.Infof("Attaching to %s stopped, session: %+v.", s.ContainerID, s)
+		util.SendCloseMessage(conn, []byte(detachMsg), msgMarshaller, writeLock)
+	}
+	waiter.Close()
+	conn.Close()
+	stdoutPipeWriter.Close()
+	stderrPipeWriter.Close()
+	stdinPipeReader.Close()
+	wg.Wait()
+}
@@ -0,0 +1,175 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/fsouza/go-dockerclient"
+	"github.com/gorilla/websocket"
+	"github.com/mijia/sweb/log"
+
+	"github.com/laincloud/entry/server/global"
+	"github.com/laincloud/entry/server/message"
+	"github.com/laincloud/entry/server/models"
+	"github.com/laincloud/entry/server/pipe"
+	"github.com/laincloud/entry/server/util"
+)
+
+const (
+	endOfLogsMsg = "\033[32m>>> End of the logs.\033[0m"
+)
+
+// logQueryKeys are the query options of /attach which make it serve the logs of the container
+var logQueryKeys = []string{"tail", "since", "until", "timestamps", "follow", "grep"}
+
+// logOptions denotes how to view the logs of the container, like docker logs
+type logOptions struct {
+	tail   string
+	since  time.Time
+	follow bool
+	filter pipe.LogFilter
+}
+
+// parseLogOptions return nil if none of the log options is given, follow is on by default just like attaching
+func parseLogOptions(r *http.Request) (*logOptions, error) {
+	query := r.URL.Query()
+	isLogs := false
+	for _, key := range logQueryKeys {
+		if _, ok := query[key]; ok {
+			isLogs = true
+		}
+	}
+	if !isLogs {
+		return nil, nil
+	}
+
+	var err error
+	now := time.Now()
+	opts := &logOptions{tail: "all", follow: true}
+	if tail := query.Get("tail"); tail != "" && tail != "all" {
+		if n, err1 := strconv.Atoi(tail); err1 != nil || n < 0 {
+			return nil, fmt.Errorf("tail: %s should be all or a non-negative number", tail)
+		}
+		opts.tail = tail
+	}
+	if since := query.Get("since"); since != "" {
+		if opts.since, err = util.ParseTime(since, now); err != nil {
+			return nil, err
+		}
+	}
+	if until := query.Get("until"); until != "" {
+		if opts.filter.Until, err = util.ParseTime(until, now); err != nil {
+			return nil, err
+		}
+	}
+	if timestamps := query.Get("timestamps"); timestamps != "" {
+		if opts.filter.Timestamps, err = strconv.ParseBool(timestamps); err != nil {
+			return nil, err
+		}
+	}
+	if follow := query.Get("follow"); follow != "" {
+		if opts.follow, err = strconv.ParseBool(follow); err != nil {
+			return nil, err
+		}
+	}
+	if grep := query.Get("grep"); grep != "" {
+		if opts.filter.Grep, err = regexp.Compile(grep); err != nil {
+			return nil, err
+		}
+	}
+	return opts, nil
+}
+
+// context return the context of reading the logs, which is done when the logs until the given time are read
+// or the websocket is closed
+func (o logOptions) context(ctx context.Context, conn *websocket.Conn) (context.Context, context.CancelFunc) {
+	var (
+		logsCtx context.Context
+		cancel  context.CancelFunc
+	)
+	if o.follow && !o.filter.Until.IsZero() {
+		logsCtx, cancel = context.WithDeadline(ctx, o.filter.Until)
+	} else {
+		logsCtx, cancel = context.WithCancel(ctx)
+	}
+	go func() {
+		// Stop following once the websocket is closed
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				cancel()
+				return
+			}
+		}
+	}()
+	return logsCtx, cancel
+}
+
+// streamLogs send the logs of the container to the client through the pipe, every line is prefixed by prefix,
+// the logs are recorded if sessionReplay is not nil
+func (o logOptions) streamLogs(ctx context.Context, p *pipe.Pipe, sessionReplay *pipe.SessionReplay, container *docker.Container, prefix, tail string, g *global.Global) error {
+	var stdout, stderr io.Writer = p.NewOutputWriter(message.ResponseMessage_STDOUT), p.NewOutputWriter(message.ResponseMessage_STDERR)
+	if sessionReplay != nil {
+		stdout, stderr = io.MultiWriter(stdout, sessionReplay), io.MultiWriter(stderr, sessionReplay)
+	}
+	stdoutWriter := pipe.NewLogWriter(stdout, o.filter, prefix)
+	stderrWriter := pipe.NewLogWriter(stderr, o.filter, prefix)
+	logsOpts := docker.LogsOptions{
+		Context:      ctx,
+		Container:    container.ID,
+		OutputStream: stdoutWriter,
+		ErrorStream:  stderrWriter,
+		Tail:         tail,
+		Follow:       o.follow,
+		Stdout:       true,
+		Stderr:       true,
+		Timestamps:   o.filter.DockerTimestamps(),
+		RawTerminal:  container.Config.Tty,
+	}
+	if !o.since.IsZero() {
+		logsOpts.Since = o.since.Unix()
+	}
+
+	err := g.DockerClient.Logs(logsOpts)
+	stdoutWriter.Close()
+	stderrWriter.Close()
+	return err
+}
+
+// attachLogs send the logs of the container to the client, and the following logs if opts.follow is on
+func attachLogs(ctx context.Context, conn *websocket.Conn, r *http.Request, s *models.Session, opts *logOptions, sessionReplay *pipe.SessionReplay, g *global.Global) {
+	msgMarshaller, msgUnmarshaller := util.GetMarshalers(r)
+	writeLock := &sync.Mutex{}
+	container, err := g.DockerClient.InspectContainer(s.ContainerID)
+	if err != nil {
+		errMsg := fmt.Sprintf(util.ErrMsgTemplate, "Can't read the logs of your container, try again.")
+		log.Errorf("g.DockerClient.InspectContainer() failed, error: %s, session: %+v.", err, s)
+		util.SendCloseMessage(conn, []byte(errMsg), msgMarshaller, writeLock)
+		return
+	}
+
+	logsCtx, cancel := opts.context(ctx, conn)
+	defer cancel()
+
+	p := pipe.NewPipe(conn, msgMarshaller, s, msgUnmarshaller, &sync.WaitGroup{}, writeLock)
+	p.Register("")
+	defer p.Unregister()
+	err = opts.streamLogs(logsCtx, p, sessionReplay, container, "", opts.tail, g)
+	switch {
+	case err == nil, err == pipe.ErrLogsUntilReached, logsCtx.Err() == context.DeadlineExceeded:
+		util.SendCloseMessage(conn, []byte(endOfLogsMsg), msgMarshaller, writeLock)
+	case ctx.Err() != nil:
+		log.Infof("Reading logs of %s canceled, session: %+v.", s.ContainerID, s)
+	case logsCtx.Err() != nil:
+		log.Infof("Websocket to %s disconnected, session: %+v.", s.ContainerID, s)
+	default:
+		errMsg := fmt.Sprintf(util.ErrMsgTemplate, "Can't read the logs of your container, try again.")
+		log.Errorf("g.DockerClient.Logs() failed, error: %s, session: %+v.", err, s)
+		util.SendCloseMessage(conn, []byte(errMsg), msgMarshaller, writeLock)
+	}
+}
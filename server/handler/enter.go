@@ -6,6 +6,7 @@ import (
 	"io"
 	"net/http"
 	"os"
+	"path"
 	"sync"
 	"time"
 
@@ -13,6 +14,7 @@ import (
 	"github.com/gorilla/websocket"
 	"github.com/mijia/sweb/log"
 
+	"github.com/laincloud/entry/server/config"
 	"github.com/laincloud/entry/server/global"
 	"github.com/laincloud/entry/server/message"
 	"github.com/laincloud/entry/server/models"
@@ -24,6 +26,13 @@ const (
 	byebyeMsg = "\033[32m>>> You quit the container safely.\033[0m"
 )
 
+// execResult is how the shell stopped, it is written before stopSignal is closed
+type execResult struct {
+	closeMsg   string
+	err        error
+	exitStatus *models.ExitStatus
+}
+
 // Enter enter to container
 func Enter(ctx context.Context, conn *websocket.Conn, r *http.Request, g *global.Global) {
 	s, err := models.NewSession(conn, r, g)
@@ -32,13 +41,37 @@ func Enter(ctx context.Context, conn *websocket.Conn, r *http.Request, g *global
 		return
 	}
 
-	g.DB.Create(s)
+	if s.ResumeToken != "" {
+		resumeExec(ctx, conn, r, s)
+		return
+	}
+
+	msgMarshaller, msgUnmarshaller := util.GetMarshalers(r)
+	writeLock := &sync.Mutex{}
+	if s.Shell, err = s.DetectShell(g.Config.Apps[s.AppName].Shell, g); err != nil {
+		errMsg := fmt.Sprintf(util.ErrMsgTemplate, "No shell is found in your container, bash, ash or sh is required.")
+		if err != models.ErrNoShell {
+			errMsg = fmt.Sprintf(util.ErrMsgTemplate, "Can't run a shell in your container, please try again later.")
+		}
+		log.Errorf("s.DetectShell() failed, error: %s, session: %+v.", err, s)
+		util.SendCloseMessage(conn, []byte(errMsg), msgMarshaller, writeLock)
+		return
+	}
+
+	if err = s.Create(g); err != nil {
+		errMsg := fmt.Sprintf(util.ErrMsgTemplate, "Can't record the session, please contact the entry owners.")
+		log.Errorf("s.Create() failed, error: %s, session: %+v.", err, s)
+		util.SendCloseMessage(conn, []byte(errMsg), msgMarshaller, writeLock)
+		return
+	}
 	defer func() {
-		g.DB.Model(s).Updates(models.Session{
-			Status:  models.SessionStatusInactive,
-			EndedAt: time.Now(),
-		})
+		s.Update(models.Session{
+			Status:        models.SessionStatusInactive,
+			CleanupStatus: s.CleanupStatus,
+			EndedAt:       time.Now(),
+		}, g)
 	}()
+	sendSessionInfo(conn, s, msgMarshaller, writeLock, g)
 
 	if err := os.MkdirAll(s.DataPath(), 0700); err != nil {
 		log.Errorf("os.MkdirAll(%s) failed, error: %s.", s.DataPath(), err)
@@ -57,19 +90,37 @@ func Enter(ctx context.Context, conn *websocket.Conn, r *http.Request, g *global
 		termType = "xterm-256color"
 	}
 
-	execCmd := []string{"env", fmt.Sprintf("TERM=%s", termType), "/bin/bash"}
+	shellCmd := s.ShellCmd()
+	var (
+		capturer pipe.CommandCapturer
+		shellEnv []string
+	)
+	switch mode := g.Config.CommandCapture(s.AppName); {
+	case mode == config.CommandCaptureShell && path.Base(s.Shell) == "bash":
+		hook, err := pipe.NewShellHook(s, g)
+		if err != nil {
+			log.Errorf("pipe.NewShellHook() failed, error: %s, session: %+v.", err, s)
+			return
+		}
+
+		capturer = hook
+		shellCmd = s.HookedShellCmd()
+		shellEnv = []string{models.HookedShellEnv(hook.RCFile())}
+	case mode == config.CommandCaptureScreen:
+		capturer = pipe.NewScreenTracker(s, g)
+	}
+
+	execCmd := append([]string{"env", fmt.Sprintf("TERM=%s", termType), s.Env()}, shellCmd...)
 	opts := docker.CreateExecOptions{
 		Container:    s.ContainerID,
 		AttachStdin:  true,
 		AttachStdout: true,
 		AttachStderr: true,
 		Tty:          true,
+		Env:          shellEnv,
 		Cmd:          execCmd,
 	}
 
-	msgMarshaller, msgUnmarshaller := util.GetMarshalers(r)
-	writeLock := &sync.Mutex{}
-
 	exec, err := g.DockerClient.CreateExec(opts)
 	if err != nil {
 		errMsg := fmt.Sprintf(util.ErrMsgTemplate, "Can't enter your container, try again.")
@@ -81,39 +132,219 @@ func Enter(ctx context.Context, conn *websocket.Conn, r *http.Request, g *global
 	stdinPipeReader, stdinPipeWriter := io.Pipe()
 	stdoutPipeReader, stdoutPipeWriter := io.Pipe()
 	stderrPipeReader, stderrPipeWriter := io.Pipe()
+	e, err := pipe.NewExec(exec.ID, s, stdinPipeWriter, stdoutPipeReader, stderrPipeReader, sessionReplay, capturer)
+	if err != nil {
+		errMsg := fmt.Sprintf(util.ErrMsgTemplate, "Can't enter your container, try again.")
+		log.Errorf("pipe.NewExec() failed, error: %s, session: %+v.", err, s)
+		util.SendCloseMessage(conn, []byte(errMsg), msgMarshaller, writeLock)
+		return
+	}
+	defer e.Close()
+	go e.WatchTimeouts(pipe.Timeouts{
+		Idle:        g.Config.IdleTimeout(s.AppName),
+		MaxDuration: g.Config.MaxDuration(s.AppName),
+		Warning:     g.Config.Session.TimeoutWarning(),
+	}, g)
+
 	stopSignal := make(chan int)
-	wg := &sync.WaitGroup{}
-	p := pipe.NewPipe(conn, msgMarshaller, s, msgUnmarshaller, wg, writeLock)
-	wg.Add(3)
-	go p.HandleAliveDetection(stopSignal)
-	go p.HandleRequest(exec.ID, stdinPipeWriter, g)
-	go p.HandleResponse(message.ResponseMessage_STDOUT, stdoutPipeReader, sessionReplay)
-	go p.HandleResponse(message.ResponseMessage_STDERR, stderrPipeReader, sessionReplay)
+	result := &execResult{}
 	go func() {
-		if err = g.DockerClient.StartExec(exec.ID, docker.StartExecOptions{
+		if err1 := g.DockerClient.StartExec(exec.ID, docker.StartExecOptions{
 			Detach:       false,
 			OutputStream: stdoutPipeWriter,
 			ErrorStream:  stderrPipeWriter,
 			InputStream:  stdinPipeReader,
 			RawTerminal:  false,
-		}); err != nil {
-			errMsg := fmt.Sprintf(util.ErrMsgTemplate, "Can't enter your container, try again.")
-			log.Errorf("Start exec failed, error: %s, session: %+v.", err.Error(), s)
-			util.SendCloseMessage(conn, []byte(errMsg), msgMarshaller, writeLock)
+		}); err1 != nil {
+			result.closeMsg = fmt.Sprintf(util.ErrMsgTemplate, "Can't enter your container, try again.")
+			result.err = err1
+			log.Errorf("Start exec failed, error: %s, session: %+v.", err1.Error(), s)
 		} else {
-			util.SendCloseMessage(conn, []byte(byebyeMsg), msgMarshaller, writeLock)
+			result.closeMsg = byebyeMsg
 		}
+		stdoutPipeWriter.Close()
+		stderrPipeWriter.Close()
 		close(stopSignal)
 	}()
 
+	var p *pipe.Pipe
+	a := pipe.NewAttachment(conn, msgMarshaller, msgUnmarshaller, writeLock)
+	for a != nil {
+		p, a = serveExec(ctx, a, e, stopSignal, result, g)
+	}
+	p.Unregister()
+
 	select {
-	case <-ctx.Done():
-		log.Infof("Entering to %s canceled, session: %+v.", s.ContainerID, s)
 	case <-stopSignal:
-		log.Infof("Entering to %s stopped, session: %+v", s.ContainerID, s)
+		if e.StopReason() != "" {
+			s.CleanupStatus = e.CleanupStatus()
+		}
+		if result.exitStatus == nil {
+			// The shell stopped while the websocket was disconnected
+			status := inspectExit(e, p, result, g)
+			result.exitStatus = &status
+		}
+	default:
+		stopExec(s, exec.ID, g)
+		reason := models.ExitReasonDisconnected
+		if ctx.Err() != nil {
+			reason = models.ExitReasonCanceled
+		}
+		status := s.InspectExit(exec.ID, reason, g)
+		result.exitStatus = &status
 	}
+	s.SaveExitStatus(*result.exitStatus, g)
 	stdoutPipeWriter.Close()
 	stderrPipeWriter.Close()
 	stdinPipeReader.Close()
-	wg.Wait()
+}
+
+// serveExec pipe the exec to the attached websocket connection until the exec stops or the connection drops,
+// it return the connection which resumes the exec within the grace period, or nil if the exec should be stopped
+func serveExec(ctx context.Context, a *pipe.Attachment, e *pipe.Exec, stopSignal <-chan int, result *execResult, g *global.Global) (*pipe.Pipe, *pipe.Attachment) {
+	s := e.Session
+	wg := &sync.WaitGroup{}
+	responseWG := &sync.WaitGroup{}
+	p := pipe.NewPipe(a.Conn, a.Marshal, s, a.UnMarshal, wg, a.WriteLock)
+	p.Register(e.ID)
+	if e.Capturer != nil {
+		p.CaptureCommands(e.Capturer)
+	}
+	p.CheckEcho(func() (bool, error) {
+		return s.EchoDisabled(e.ID, g)
+	})
+	p.SendMessage(message.ResponseMessage_RESUME_TOKEN, []byte(e.ResumeToken))
+
+	aliveStop := make(chan int)
+	disconnected := make(chan int)
+	stdoutReader := e.Stdout.NewReader()
+	stderrReader := e.Stderr.NewReader()
+	wg.Add(3)
+	responseWG.Add(2)
+	go p.HandleAliveDetection(aliveStop)
+	go func() {
+		p.HandleRequest(e.ID, e.NewStdinWriter(), g)
+		close(disconnected)
+	}()
+	go func() {
+		p.HandleResponse(message.ResponseMessage_STDOUT, stdoutReader, nil)
+		responseWG.Done()
+	}()
+	go func() {
+		p.HandleResponse(message.ResponseMessage_STDERR, stderrReader, nil)
+		responseWG.Done()
+	}()
+	once := sync.Once{}
+	detach := func() {
+		once.Do(func() {
+			close(aliveStop)
+			stdoutReader.Close()
+			stderrReader.Close()
+			wg.Wait()
+			a.Detach()
+		})
+	}
+	defer detach()
+
+	select {
+	case <-ctx.Done():
+		log.Infof("Entering to %s canceled, session: %+v.", s.ContainerID, s)
+		a.Conn.Close()
+		return p, nil
+	case <-stopSignal:
+		log.Infof("Entering to %s stopped, session: %+v", s.ContainerID, s)
+		responseWG.Wait()
+		status := inspectExit(e, p, result, g)
+		result.exitStatus = &status
+		closeMsg := result.closeMsg
+		if e.StopReason() != "" {
+			closeMsg = fmt.Sprintf(util.ErrMsgTemplate, "Your session has timed out.")
+		}
+		if !p.Terminated() {
+			p.SendExitStatus(status, []byte(closeMsg))
+		}
+		return p, nil
+	case next := <-e.Attachments():
+		log.Infof("Entering to %s resumed by another connection, session: %+v.", s.ContainerID, s)
+		a.Conn.Close()
+		return p, next
+	case <-disconnected:
+		log.Infof("Websocket to %s disconnected, will wait for resuming, session: %+v.", s.ContainerID, s)
+	}
+
+	// Keep the output unread for the resuming connection
+	detach()
+
+	select {
+	case <-ctx.Done():
+		log.Infof("Entering to %s canceled, session: %+v.", s.ContainerID, s)
+		return p, nil
+	case <-stopSignal:
+		log.Infof("Entering to %s stopped while disconnected, session: %+v", s.ContainerID, s)
+		return p, nil
+	case next := <-e.Attachments():
+		log.Infof("Entering to %s resumed, session: %+v.", s.ContainerID, s)
+		return p, next
+	case <-time.After(g.Config.Session.ResumeGracePeriod()):
+		log.Infof("Entering to %s is not resumed in %s, session: %+v.", s.ContainerID, g.Config.Session.ResumeGracePeriod(), s)
+		return p, nil
+	}
+}
+
+// inspectExit inspect the exit status of the shell which stopped by itself, timed out or was terminated by entry owners
+func inspectExit(e *pipe.Exec, p *pipe.Pipe, result *execResult, g *global.Global) models.ExitStatus {
+	reason := models.ExitReasonExited
+	switch {
+	case result.err != nil:
+		reason = models.ExitReasonError
+	case e.StopReason() != "":
+		reason = e.StopReason()
+	case p.Terminated():
+		reason = models.ExitReasonTerminated
+	}
+	return e.Session.InspectExit(e.ID, reason, g)
+}
+
+// isSameTarget return whether the resuming session targets the container of the exec,
+// the instance picked by entry for the resuming session may differ from the exec's, so only the proc is compared then
+func isSameTarget(execSession, s *models.Session) bool {
+	if s.InstancePicked {
+		return execSession.AppName == s.AppName && execSession.ProcName == s.ProcName
+	}
+
+	return execSession.ContainerID == s.ContainerID
+}
+
+// sendSessionInfo tell the client which session and container it has entered
+func sendSessionInfo(conn *websocket.Conn, s *models.Session, msgMarshaller util.Marshaler, writeLock *sync.Mutex, g *global.Global) {
+	util.SendMessage(conn, &message.ResponseMessage{
+		MsgType: message.ResponseMessage_SESSION_INFO,
+		SessionInfo: &message.SessionInfo{
+			SessionID:   s.SessionID,
+			ContainerID: s.ContainerID,
+			NodeIP:      s.NodeIP,
+			Banner:      g.Config.Banner(s.AppName),
+			InstanceNo:  s.InstanceNo,
+		},
+	}, msgMarshaller, writeLock)
+}
+
+// resumeExec hand the websocket connection over to the exec which the user left
+func resumeExec(ctx context.Context, conn *websocket.Conn, r *http.Request, s *models.Session) {
+	msgMarshaller, msgUnmarshaller := util.GetMarshalers(r)
+	writeLock := &sync.Mutex{}
+	e, ok := pipe.GetResumableExec(s.ResumeToken)
+	if !ok || e.Session.User != s.User || !isSameTarget(e.Session, s) {
+		errMsg := fmt.Sprintf(util.ErrMsgTemplate, "Session can't be resumed, please enter again.")
+		log.Errorf("Resume token is invalid, session: %+v.", s)
+		util.SendCloseMessage(conn, []byte(errMsg), msgMarshaller, writeLock)
+		return
+	}
+
+	log.Infof("%s is resuming session: %+v.", s.User, e.Session)
+	if err := e.Resume(ctx, pipe.NewAttachment(conn, msgMarshaller, msgUnmarshaller, writeLock)); err != nil {
+		errMsg := fmt.Sprintf(util.ErrMsgTemplate, "Session can't be resumed, please enter again.")
+		log.Errorf("e.Resume() failed, error: %s, session: %+v.", err, e.Session)
+		util.SendCloseMessage(conn, []byte(errMsg), msgMarshaller, writeLock)
+	}
 }
@@ -0,0 +1,146 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/fsouza/go-dockerclient"
+	"github.com/gorilla/websocket"
+	"github.com/mijia/sweb/log"
+
+	"github.com/laincloud/entry/server/global"
+	"github.com/laincloud/entry/server/message"
+	"github.com/laincloud/entry/server/models"
+	"github.com/laincloud/entry/server/pipe"
+	"github.com/laincloud/entry/server/util"
+)
+
+// Exec run a command in the container without tty, and report its exit code when it exits
+func Exec(ctx context.Context, conn *websocket.Conn, r *http.Request, g *global.Global) {
+	s, err := models.NewSession(conn, r, g)
+	if err != nil {
+		log.Errorf("models.NewSession() failed, error: %s.", err)
+		return
+	}
+
+	msgMarshaller, msgUnmarshaller := util.GetMarshalers(r)
+	writeLock := &sync.Mutex{}
+	if len(s.Command) == 0 {
+		errMsg := fmt.Sprintf(util.ErrMsgTemplate, "command is required.")
+		log.Errorf("Command is empty, session: %+v.", s)
+		util.SendCloseMessage(conn, []byte(errMsg), msgMarshaller, writeLock)
+		return
+	}
+
+	s.Type = models.SessionTypeExec
+	if err = s.Create(g); err != nil {
+		errMsg := fmt.Sprintf(util.ErrMsgTemplate, "Can't record the session, please contact the entry owners.")
+		log.Errorf("s.Create() failed, error: %s, session: %+v.", err, s)
+		util.SendCloseMessage(conn, []byte(errMsg), msgMarshaller, writeLock)
+		return
+	}
+	defer func() {
+		s.Update(models.Session{
+			Status:        models.SessionStatusInactive,
+			CleanupStatus: s.CleanupStatus,
+			EndedAt:       time.Now(),
+		}, g)
+	}()
+
+	p := pipe.NewPipe(conn, msgMarshaller, s, msgUnmarshaller, &sync.WaitGroup{}, writeLock)
+	p.SaveCommand(s.CommandLine(), g)
+	exec, err := g.DockerClient.CreateExec(docker.CreateExecOptions{
+		Container:    s.ContainerID,
+		AttachStdout: true,
+		AttachStderr: true,
+		Cmd:          append([]string{"env", s.Env()}, s.Command...),
+	})
+	if err != nil {
+		errMsg := fmt.Sprintf(util.ErrMsgTemplate, "Create exec failed.")
+		log.Errorf("g.DockerClient.CreateExec() failed, error: %s, session: %+v.", err, s)
+		util.SendCloseMessage(conn, []byte(errMsg), msgMarshaller, writeLock)
+		return
+	}
+
+	p.Register(exec.ID)
+	defer p.Unregister()
+
+	stopSignal := make(chan int)
+	go p.HandleAliveDetection(stopSignal)
+	defer close(stopSignal)
+
+	disconnected := make(chan struct{})
+	go func() {
+		// The command has no stdin, the messages from the client are only read for HELLO, SIGNAL and to detect disconnection
+		for {
+			_, wsMsg, err1 := conn.ReadMessage()
+			if err1 != nil {
+				close(disconnected)
+				return
+			}
+
+			inMsg := message.RequestMessage{}
+			if msgUnmarshaller(wsMsg, &inMsg) != nil {
+				continue
+			}
+
+			switch inMsg.MsgType {
+			case message.RequestMessage_HELLO:
+				p.Handshake(inMsg.Hello, util.DetectEncoding(wsMsg))
+			case message.RequestMessage_SIGNAL:
+				if err2 := p.HandleSignal(string(inMsg.Content), g); err2 != nil {
+					log.Errorf("p.HandleSignal(%s) failed, error: %s, session: %+v.", inMsg.Content, err2, s)
+				}
+			}
+		}
+	}()
+
+	execErr := make(chan error, 1)
+	go func() {
+		execErr <- g.DockerClient.StartExec(exec.ID, docker.StartExecOptions{
+			OutputStream: p.NewOutputWriter(message.ResponseMessage_STDOUT),
+			ErrorStream:  p.NewOutputWriter(message.ResponseMessage_STDERR),
+		})
+	}()
+
+	select {
+	case err = <-execErr:
+	case <-disconnected:
+		log.Infof("Websocket of exec: %s is disconnected, session: %+v.", exec.ID, s)
+		stopExec(s, exec.ID, g)
+		s.SaveExitStatus(s.InspectExit(exec.ID, models.ExitReasonDisconnected, g), g)
+		return
+	case <-ctx.Done():
+		log.Infof("Exec: %s is canceled, session: %+v.", exec.ID, s)
+		stopExec(s, exec.ID, g)
+		s.SaveExitStatus(s.InspectExit(exec.ID, models.ExitReasonCanceled, g), g)
+		conn.Close()
+		return
+	}
+
+	switch {
+	case err != nil:
+		log.Errorf("g.DockerClient.StartExec() failed, error: %s, session: %+v.", err, s)
+		status := s.InspectExit(exec.ID, models.ExitReasonError, g)
+		s.SaveExitStatus(status, g)
+		p.SendExitStatus(status, []byte(fmt.Sprintf(util.ErrMsgTemplate, "Run command failed.")))
+	case p.Terminated():
+		// The client has been told by the terminator
+		s.SaveExitStatus(s.InspectExit(exec.ID, models.ExitReasonTerminated, g), g)
+	default:
+		status := s.InspectExit(exec.ID, models.ExitReasonExited, g)
+		s.SaveExitStatus(status, g)
+		p.SendExitStatus(status, []byte(fmt.Sprintf("Command exited with code %d.", status.Code)))
+	}
+}
+
+func stopExec(s *models.Session, execID string, g *global.Global) {
+	err := s.StopExec(execID, g.Config.Session.CleanupGracePeriod(), g)
+	if err != nil {
+		log.Errorf("s.StopExec() failed, error: %s, session: %+v.", err, s)
+	}
+	s.CleanupStatus = models.CleanupStatusOf(err)
+}
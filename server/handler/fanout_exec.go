@@ -0,0 +1,246 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/fsouza/go-dockerclient"
+	"github.com/gorilla/websocket"
+	lainletmessage "github.com/laincloud/lainlet/message"
+	"github.com/mijia/sweb/log"
+
+	"github.com/laincloud/entry/server/global"
+	"github.com/laincloud/entry/server/message"
+	"github.com/laincloud/entry/server/models"
+	"github.com/laincloud/entry/server/pipe"
+	"github.com/laincloud/entry/server/util"
+)
+
+// FanoutExec run a command on the selected instances of the proc concurrently without tty,
+// the output is streamed line by line and tagged by the instance, followed by the exit status of every instance,
+// the whole operation is audited as a fan-out session with an exec session for each instance
+func FanoutExec(ctx context.Context, conn *websocket.Conn, r *http.Request, g *global.Global) {
+	s, err := models.NewProcSession(conn, r, g)
+	if err != nil {
+		log.Errorf("models.NewProcSession() failed, error: %s.", err)
+		return
+	}
+
+	msgMarshaller, msgUnmarshaller := util.GetMarshalers(r)
+	writeLock := &sync.Mutex{}
+	if len(s.Command) == 0 {
+		errMsg := fmt.Sprintf(util.ErrMsgTemplate, "command is required.")
+		log.Errorf("Command is empty, session: %+v.", s)
+		util.SendCloseMessage(conn, []byte(errMsg), msgMarshaller, writeLock)
+		return
+	}
+
+	containers, err := selectContainers(s, g)
+	if err != nil {
+		errMsg := fmt.Sprintf(util.ErrMsgTemplate, fmt.Sprintf("Can't select the instances: %s.", err))
+		log.Errorf("selectContainers() failed, error: %s, session: %+v.", err, s)
+		util.SendCloseMessage(conn, []byte(errMsg), msgMarshaller, writeLock)
+		return
+	}
+
+	s.Type = models.SessionTypeFanout
+	if err = s.Create(g); err != nil {
+		errMsg := fmt.Sprintf(util.ErrMsgTemplate, "Can't record the session, please contact the entry owners.")
+		log.Errorf("s.Create() failed, error: %s, session: %+v.", err, s)
+		util.SendCloseMessage(conn, []byte(errMsg), msgMarshaller, writeLock)
+		return
+	}
+	defer func() {
+		s.Update(models.Session{
+			Status:  models.SessionStatusInactive,
+			EndedAt: time.Now(),
+		}, g)
+	}()
+	sendSessionInfo(conn, s, msgMarshaller, writeLock, g)
+
+	p := pipe.NewPipe(conn, msgMarshaller, s, msgUnmarshaller, &sync.WaitGroup{}, writeLock)
+	p.Register("")
+	defer p.Unregister()
+	p.SaveCommand(s.CommandLine(), g)
+
+	stopSignal := make(chan int)
+	go p.HandleAliveDetection(stopSignal)
+	defer close(stopSignal)
+
+	// The commands start right away, while the EXIT of each instance depends on the features of the client,
+	// which are known once HELLO arrives, so the exit statuses are held until then
+	p.HoldInstanceExits()
+	disconnected := make(chan struct{})
+	go func() {
+		// The commands have no stdin, the messages from the client are only read for HELLO and to detect disconnection
+		for {
+			_, wsMsg, err1 := conn.ReadMessage()
+			if err1 != nil {
+				close(disconnected)
+				return
+			}
+
+			inMsg := message.RequestMessage{}
+			if msgUnmarshaller(wsMsg, &inMsg) == nil && inMsg.MsgType == message.RequestMessage_HELLO {
+				p.Handshake(inMsg.Hello, util.DetectEncoding(wsMsg))
+			}
+		}
+	}()
+
+	statuses := make([]models.ExitStatus, len(containers))
+	wg := &sync.WaitGroup{}
+	wg.Add(len(containers))
+	for i, c := range containers {
+		go func(i int, c instanceContainer) {
+			statuses[i] = fanoutInstance(ctx, disconnected, p, s, c, g)
+			wg.Done()
+		}(i, c)
+	}
+	wg.Wait()
+	// The exit statuses still held are sent as STDERR, since the client sent no HELLO before all commands exited
+	p.ReleaseInstanceExits()
+
+	status := models.ExitStatus{Reason: models.ExitReasonExited, ContainerRunning: true}
+	failed := 0
+	for _, st := range statuses {
+		if st.Code != 0 {
+			failed++
+			if status.Code == 0 {
+				status.Code = st.Code
+			}
+		}
+		status.ContainerRunning = status.ContainerRunning && st.ContainerRunning
+	}
+	select {
+	case <-ctx.Done():
+		status.Reason = models.ExitReasonCanceled
+		conn.Close()
+	case <-disconnected:
+		status.Reason = models.ExitReasonDisconnected
+		if p.Terminated() {
+			status.Reason = models.ExitReasonTerminated
+		}
+	default:
+		p.SendExitStatus(status, []byte(fmt.Sprintf("Command exited on %d instances, %d of them failed.", len(statuses), failed)))
+	}
+	s.SaveExitStatus(status, g)
+}
+
+// instanceContainer is the container of an instance
+type instanceContainer struct {
+	instanceNo string
+	container  *lainletmessage.Container
+}
+
+// selectContainers return the containers of the instances selected by the instance number of the session,
+// sorted by the instance number
+func selectContainers(s *models.Session, g *global.Global) ([]instanceContainer, error) {
+	selector, err := util.ParseInstanceSelector(s.InstanceNo)
+	if err != nil {
+		return nil, err
+	}
+
+	containers, err := util.GetProcContainers(s.AppName, s.ProcName, g)
+	if err != nil {
+		return nil, err
+	}
+
+	var selected []instanceContainer
+	for instanceNo, c := range containers {
+		if selector.Match(instanceNo) {
+			selected = append(selected, instanceContainer{instanceNo: instanceNo, container: c})
+		}
+	}
+	if len(selected) == 0 {
+		return nil, fmt.Errorf("no instance of %s[%s] matches %s", s.AppName, s.ProcName, s.InstanceNo)
+	}
+
+	sort.Slice(selected, func(i, j int) bool {
+		a, _ := strconv.Atoi(selected[i].instanceNo)
+		b, _ := strconv.Atoi(selected[j].instanceNo)
+		return a < b
+	})
+	return selected, nil
+}
+
+// fanoutInstance run the command of the fan-out session on the instance as an exec session of its own
+func fanoutInstance(ctx context.Context, disconnected <-chan struct{}, p *pipe.Pipe, parent *models.Session, c instanceContainer, g *global.Global) models.ExitStatus {
+	s := *parent
+	s.SessionID = 0
+	s.CreatedAt, s.UpdatedAt = time.Time{}, time.Time{}
+	s.ParentID = parent.SessionID
+	s.Type = models.SessionTypeExec
+	s.InstanceNo = c.instanceNo
+	s.ContainerID = c.container.Id
+	s.NodeIP = c.container.NodeIp
+	if err := s.Create(g); err != nil {
+		log.Errorf("s.Create() failed, error: %s, session: %+v.", err, s)
+		status := models.ExitStatus{Code: models.UnknownExitCode, Reason: models.ExitReasonError}
+		p.SendInstanceExit(c.instanceNo, status)
+		return status
+	}
+	defer func() {
+		s.Update(models.Session{
+			Status:        models.SessionStatusInactive,
+			CleanupStatus: s.CleanupStatus,
+			EndedAt:       time.Now(),
+		}, g)
+	}()
+
+	var status models.ExitStatus
+	defer func() {
+		s.SaveExitStatus(status, g)
+		p.SendInstanceExit(c.instanceNo, status)
+	}()
+
+	exec, err := g.DockerClient.CreateExec(docker.CreateExecOptions{
+		Container:    s.ContainerID,
+		AttachStdout: true,
+		AttachStderr: true,
+		Cmd:          append([]string{"env", s.Env()}, s.Command...),
+	})
+	if err != nil {
+		log.Errorf("g.DockerClient.CreateExec() failed, error: %s, session: %+v.", err, s)
+		status = s.InspectExit("", models.ExitReasonError, g)
+		return status
+	}
+
+	stdoutWriter := pipe.NewLogWriter(p.NewInstanceOutputWriter(message.ResponseMessage_STDOUT, c.instanceNo), pipe.LogFilter{}, "")
+	stderrWriter := pipe.NewLogWriter(p.NewInstanceOutputWriter(message.ResponseMessage_STDERR, c.instanceNo), pipe.LogFilter{}, "")
+	execErr := make(chan error, 1)
+	go func() {
+		execErr <- g.DockerClient.StartExec(exec.ID, docker.StartExecOptions{
+			OutputStream: stdoutWriter,
+			ErrorStream:  stderrWriter,
+		})
+	}()
+
+	reason := models.ExitReasonExited
+	select {
+	case err = <-execErr:
+		if err != nil {
+			log.Errorf("g.DockerClient.StartExec() failed, error: %s, session: %+v.", err, s)
+			reason = models.ExitReasonError
+		}
+	case <-disconnected:
+		stopExec(&s, exec.ID, g)
+		<-execErr
+		reason = models.ExitReasonDisconnected
+		if p.Terminated() {
+			reason = models.ExitReasonTerminated
+		}
+	case <-ctx.Done():
+		stopExec(&s, exec.ID, g)
+		<-execErr
+		reason = models.ExitReasonCanceled
+	}
+	stdoutWriter.Close()
+	stderrWriter.Close()
+	status = s.InspectExit(exec.ID, reason, g)
+	return status
+}
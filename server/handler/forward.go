@@ -0,0 +1,76 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/mijia/sweb/log"
+
+	"github.com/laincloud/entry/server/global"
+	"github.com/laincloud/entry/server/models"
+	"github.com/laincloud/entry/server/pipe"
+	"github.com/laincloud/entry/server/util"
+)
+
+// Forward forward TCP connections to a port inside the container
+func Forward(ctx context.Context, conn *websocket.Conn, r *http.Request, g *global.Global) {
+	s, err := models.NewSession(conn, r, g)
+	if err != nil {
+		log.Errorf("models.NewSession() failed, error: %s.", err)
+		return
+	}
+
+	msgMarshaller, msgUnmarshaller := util.GetMarshalers(r)
+	writeLock := &sync.Mutex{}
+	if s.TargetPort <= 0 || s.TargetPort > 65535 {
+		errMsg := fmt.Sprintf(util.ErrMsgTemplate, "target-port is invalid.")
+		log.Errorf("Target port: %d is invalid, session: %+v.", s.TargetPort, s)
+		util.SendCloseMessage(conn, []byte(errMsg), msgMarshaller, writeLock)
+		return
+	}
+
+	s.Type = models.SessionTypeForward
+	if err = s.Create(g); err != nil {
+		errMsg := fmt.Sprintf(util.ErrMsgTemplate, "Can't record the session, please contact the entry owners.")
+		log.Errorf("s.Create() failed, error: %s, session: %+v.", err, s)
+		util.SendCloseMessage(conn, []byte(errMsg), msgMarshaller, writeLock)
+		return
+	}
+	p := pipe.NewPipe(conn, msgMarshaller, s, msgUnmarshaller, &sync.WaitGroup{}, writeLock)
+	f := pipe.NewForwarder(p, s.TargetPort)
+	p.Register("")
+	defer func() {
+		p.Unregister()
+		// The helpers of the streams may have exited already
+		if err1 := s.Signal("", "KILL", g); err1 != nil && err1 != models.ErrNoProcess {
+			log.Errorf("s.Signal(KILL) failed, error: %s, session: %+v.", err1, s)
+		}
+		s.Update(models.Session{
+			Status:   models.SessionStatusInactive,
+			BytesIn:  atomic.LoadInt64(&f.BytesIn),
+			BytesOut: atomic.LoadInt64(&f.BytesOut),
+			EndedAt:  time.Now(),
+		}, g)
+	}()
+
+	stopSignal := make(chan int)
+	go p.HandleAliveDetection(stopSignal)
+	go func() {
+		f.Serve(g)
+		close(stopSignal)
+	}()
+
+	select {
+	case <-ctx.Done():
+		log.Infof("Forwarding to %s:%d canceled, session: %+v.", s.ContainerID, s.TargetPort, s)
+		conn.Close()
+		<-stopSignal
+	case <-stopSignal:
+		log.Infof("Forwarding to %s:%d stopped, session: %+v.", s.ContainerID, s.TargetPort, s)
+	}
+}
@@ -0,0 +1,37 @@
+package handler
+
+import (
+	"time"
+
+	"github.com/go-openapi/runtime/middleware"
+
+	swaggermodels "github.com/laincloud/entry/server/gen/models"
+
+	"github.com/laincloud/entry/server/gen/restapi/operations/file_transfers"
+	"github.com/laincloud/entry/server/global"
+	"github.com/laincloud/entry/server/models"
+)
+
+// ListFileTransfers list file transfers in database
+func ListFileTransfers(params file_transfers.ListFileTransfersParams, g *global.Global) middleware.Responder {
+	newDB := g.DB.Joins("inner join sessions on sessions.session_id = file_transfers.session_id")
+	since := time.Unix(*params.Since, 0)
+	newDB = newDB.Where("file_transfers.created_at > ?", since)
+	if params.AppName != nil && *params.AppName != "" {
+		newDB = newDB.Where("sessions.app_name LIKE ?", *params.AppName)
+	}
+	if params.User != nil && *params.User != "" {
+		newDB = newDB.Where("file_transfers.user LIKE ?", *params.User)
+	}
+	if params.SessionID != nil && *params.SessionID != 0 {
+		newDB = newDB.Where("file_transfers.session_id = ?", *params.SessionID)
+	}
+	var dbFileTransfers []models.FileTransfer
+	newDB.Order("file_transfers.file_transfer_id desc").Limit(*params.Limit).Offset(*params.Offset).Preload("Session").Find(&dbFileTransfers)
+	payload := make([]*swaggermodels.FileTransfer, len(dbFileTransfers))
+	for i, dbFileTransfer := range dbFileTransfers {
+		swaggerFileTransfer := dbFileTransfer.SwaggerModel()
+		payload[i] = &swaggerFileTransfer
+	}
+	return file_transfers.NewListFileTransfersOK().WithPayload(payload)
+}
@@ -0,0 +1,40 @@
+package handler
+
+import (
+	"time"
+
+	"github.com/go-openapi/runtime/middleware"
+
+	swaggermodels "github.com/laincloud/entry/server/gen/models"
+
+	"github.com/laincloud/entry/server/gen/restapi/operations/session_events"
+	"github.com/laincloud/entry/server/global"
+	"github.com/laincloud/entry/server/models"
+)
+
+// ListSessionEvents list audit events of sessions in database
+func ListSessionEvents(params session_events.ListSessionEventsParams, g *global.Global) middleware.Responder {
+	newDB := g.DB.Joins("inner join sessions on sessions.session_id = session_events.session_id")
+	since := time.Unix(*params.Since, 0)
+	newDB = newDB.Where("session_events.created_at > ?", since)
+	if params.AppName != nil && *params.AppName != "" {
+		newDB = newDB.Where("sessions.app_name LIKE ?", *params.AppName)
+	}
+	if params.User != nil && *params.User != "" {
+		newDB = newDB.Where("session_events.user LIKE ?", *params.User)
+	}
+	if params.SessionID != nil && *params.SessionID != 0 {
+		newDB = newDB.Where("session_events.session_id = ?", *params.SessionID)
+	}
+	if params.Type != nil && *params.Type != "" {
+		newDB = newDB.Where("session_events.type = ?", *params.Type)
+	}
+	var dbSessionEvents []models.SessionEvent
+	newDB.Order("session_events.event_id desc").Limit(*params.Limit).Offset(*params.Offset).Preload("Session").Find(&dbSessionEvents)
+	payload := make([]*swaggermodels.SessionEvent, len(dbSessionEvents))
+	for i, dbSessionEvent := range dbSessionEvents {
+		swaggerSessionEvent := dbSessionEvent.SwaggerModel()
+		payload[i] = &swaggerSessionEvent
+	}
+	return session_events.NewListSessionEventsOK().WithPayload(payload)
+}
@@ -26,6 +26,12 @@ func ListSessions(params sessions.ListSessionsParams, g *global.Global) middlewa
 	if params.AppName != nil && *params.AppName != "" {
 		newDB = newDB.Where("app_name LIKE ?", *params.AppName)
 	}
+	if params.Type != nil && *params.Type != "" {
+		newDB = newDB.Where("type = ?", *params.Type)
+	}
+	if params.ParentID != nil {
+		newDB = newDB.Where("parent_id = ?", *params.ParentID)
+	}
 	newDB.Order("session_id desc").Limit(*params.Limit).Offset(*params.Offset).Find(&dbSessions)
 	payload := make([]*swaggermodels.Session, len(dbSessions))
 	for i, dbSession := range dbSessions {
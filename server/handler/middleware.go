@@ -13,18 +13,28 @@ import (
 	"github.com/laincloud/entry/server/config"
 	swaggermodels "github.com/laincloud/entry/server/gen/models"
 	"github.com/laincloud/entry/server/global"
+	"github.com/laincloud/entry/server/sso"
 	"github.com/laincloud/entry/server/util"
 )
 
 const (
 	keyAccessToken = "access_token"
 	readBufferSize = 1024
+
+	// keyUser is the key of the user authenticated by AuthAPI in the context of the request
+	keyUser contextKey = "user"
 )
 
+type contextKey string
+
 var (
 	noAuthAPIPaths = []string{
 		"/enter",
 		"/attach",
+		"/forward",
+		"/api/exec",
+		"/api/fanout_exec",
+		"/api/proc_logs",
 		"/api/authorize",
 		"/api/config",
 		"/api/logout",
@@ -63,7 +73,8 @@ func AuthAPI(h http.Handler, g *global.Global) http.Handler {
 			return
 		}
 
-		if _, err = util.AuthAPI(accessToken.Value, g); err != nil {
+		user, err := util.AuthAPI(accessToken.Value, g)
+		if err != nil {
 			errMsg := err.Error()
 			w.WriteHeader(http.StatusUnauthorized)
 			json.NewEncoder(w).Encode(swaggermodels.Error{
@@ -72,10 +83,16 @@ func AuthAPI(h http.Handler, g *global.Global) http.Handler {
 			return
 		}
 
-		h.ServeHTTP(w, r)
+		h.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), keyUser, user)))
 	})
 }
 
+// authenticatedUser return the user authenticated by AuthAPI for the request
+func authenticatedUser(r *http.Request) (*sso.User, bool) {
+	user, ok := r.Context().Value(keyUser).(*sso.User)
+	return user, ok
+}
+
 type websocketHandlerFunc func(ctx context.Context, conn *websocket.Conn, r *http.Request, g *global.Global)
 
 // HandleWebsocket handle websocket request
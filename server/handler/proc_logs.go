@@ -0,0 +1,187 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/mijia/sweb/log"
+
+	"github.com/laincloud/entry/server/global"
+	"github.com/laincloud/entry/server/message"
+	"github.com/laincloud/entry/server/models"
+	"github.com/laincloud/entry/server/pipe"
+	"github.com/laincloud/entry/server/util"
+)
+
+const (
+	procRefreshInterval = 10 * time.Second
+	instanceNoticeTmpl  = "\033[32m>>> Instance %s(%s) %s.\033[0m\n"
+)
+
+// ProcLogs stream the logs of all instances of the proc, every line is prefixed by the instance number,
+// the instances which appear or disappear during the stream are picked up
+func ProcLogs(ctx context.Context, conn *websocket.Conn, r *http.Request, g *global.Global) {
+	s, err := models.NewProcSession(conn, r, g)
+	if err != nil {
+		log.Errorf("models.NewProcSession() failed, error: %s.", err)
+		return
+	}
+
+	msgMarshaller, msgUnmarshaller := util.GetMarshalers(r)
+	writeLock := &sync.Mutex{}
+	opts, err := parseLogOptions(r)
+	if err != nil {
+		errMsg := fmt.Sprintf(util.ErrMsgTemplate, fmt.Sprintf("Invalid log options: %s.", err))
+		log.Errorf("parseLogOptions() failed, error: %s, session: %+v.", err, s)
+		util.SendCloseMessage(conn, []byte(errMsg), msgMarshaller, writeLock)
+		return
+	}
+	if opts == nil {
+		// Only the new output, just like attaching
+		opts = &logOptions{tail: "0", follow: true}
+	}
+
+	// The output of all instances is recorded in one attach session, whose instance number * means the whole proc
+	s.InstanceNo = "*"
+	sessionReplay, endSession, err := recordAttachSession(conn, s, msgMarshaller, writeLock, g)
+	if err != nil {
+		return
+	}
+	defer endSession()
+
+	logsCtx, cancel := opts.context(ctx, conn)
+	defer cancel()
+
+	ps := &procStreams{
+		ctx:           logsCtx,
+		opts:          opts,
+		sessionReplay: sessionReplay,
+		p:             pipe.NewPipe(conn, msgMarshaller, s, msgUnmarshaller, &sync.WaitGroup{}, writeLock),
+		s:             s,
+		streams:       make(map[string]context.CancelFunc),
+		ended:         make(map[string]time.Time),
+	}
+	ps.p.Register("")
+	defer ps.p.Unregister()
+	if err = ps.refresh(opts.tail, g); err != nil {
+		errMsg := fmt.Sprintf(util.ErrMsgTemplate, "Can't find the instances of your proc, try again.")
+		log.Errorf("ps.refresh() failed, error: %s, session: %+v.", err, s)
+		util.SendCloseMessage(conn, []byte(errMsg), msgMarshaller, writeLock)
+		return
+	}
+
+	if opts.follow {
+		ticker := time.NewTicker(procRefreshInterval)
+	loop:
+		for {
+			select {
+			case <-logsCtx.Done():
+				break loop
+			case <-ticker.C:
+				// The instances appearing later are new, so all of their logs are sent
+				if err = ps.refresh("all", g); err != nil {
+					log.Errorf("ps.refresh() failed, error: %s, session: %+v.", err, s)
+				}
+			}
+		}
+		ticker.Stop()
+	}
+	ps.wg.Wait()
+
+	switch {
+	case ctx.Err() != nil:
+		log.Infof("Streaming logs of %s[%s] canceled, session: %+v.", s.AppName, s.ProcName, s)
+	case logsCtx.Err() == context.Canceled:
+		log.Infof("Websocket of %s[%s] disconnected, session: %+v.", s.AppName, s.ProcName, s)
+	default:
+		util.SendCloseMessage(conn, []byte(endOfLogsMsg), msgMarshaller, writeLock)
+	}
+}
+
+// procStreams are the log streams of the instances of the proc, keyed by the container ID
+type procStreams struct {
+	ctx           context.Context
+	opts          *logOptions
+	p             *pipe.Pipe
+	s             *models.Session
+	sessionReplay *pipe.SessionReplay
+	lock          sync.Mutex
+	streams       map[string]context.CancelFunc
+	ended         map[string]time.Time // when the streams ended by themselves, such as the container restarted
+	wg            sync.WaitGroup
+}
+
+// refresh start streaming the logs of the new instances and the ones whose streams ended,
+// and stop streaming the ones which disappeared
+func (ps *procStreams) refresh(tail string, g *global.Global) error {
+	containers, err := util.GetProcContainers(ps.s.AppName, ps.s.ProcName, g)
+	if err != nil {
+		return err
+	}
+
+	ps.lock.Lock()
+	defer ps.lock.Unlock()
+	alive := make(map[string]bool)
+	for instanceNo, c := range containers {
+		alive[c.Id] = true
+		if _, ok := ps.streams[c.Id]; !ok {
+			opts := *ps.opts
+			if ended, ok := ps.ended[c.Id]; ok && ended.After(opts.since) {
+				// Only the logs after the last stream are sent, the logs within the same second may be sent again
+				opts.since = ended
+			}
+			ctx, cancel := context.WithCancel(ps.ctx)
+			ps.streams[c.Id] = cancel
+			ps.wg.Add(1)
+			go ps.stream(ctx, cancel, opts, instanceNo, c.Id, tail, g)
+		}
+	}
+	for id, cancel := range ps.streams {
+		if !alive[id] {
+			cancel()
+			delete(ps.streams, id)
+		}
+	}
+	for id := range ps.ended {
+		if !alive[id] {
+			delete(ps.ended, id)
+		}
+	}
+	return nil
+}
+
+func (ps *procStreams) stream(ctx context.Context, cancel context.CancelFunc, opts logOptions, instanceNo, containerID, tail string, g *global.Global) {
+	defer ps.wg.Done()
+	defer func() {
+		// The stream which ended by itself is removed, so that the next refresh attaches the instance again
+		ps.lock.Lock()
+		if ctx.Err() == nil {
+			delete(ps.streams, containerID)
+			ps.ended[containerID] = time.Now()
+		}
+		ps.lock.Unlock()
+		cancel()
+	}()
+	container, err := g.DockerClient.InspectContainer(containerID)
+	if err != nil {
+		log.Errorf("g.DockerClient.InspectContainer(%s) failed, error: %s, session: %+v.", containerID, err, ps.s)
+		return
+	}
+
+	shortID := containerID
+	if len(shortID) > 12 {
+		shortID = shortID[:12]
+	}
+	if opts.follow {
+		ps.p.SendMessage(message.ResponseMessage_STDERR, []byte(fmt.Sprintf(instanceNoticeTmpl, instanceNo, shortID, "joined")))
+		defer ps.p.SendMessage(message.ResponseMessage_STDERR, []byte(fmt.Sprintf(instanceNoticeTmpl, instanceNo, shortID, "left")))
+	}
+
+	if err = opts.streamLogs(ctx, ps.p, ps.sessionReplay, container, fmt.Sprintf("[%s] ", instanceNo), tail, g); err != nil && err != pipe.ErrLogsUntilReached && ctx.Err() == nil {
+		log.Errorf("Streaming logs of %s failed, error: %s, session: %+v.", containerID, err, ps.s)
+	}
+}
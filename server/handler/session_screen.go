@@ -0,0 +1,61 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/mijia/sweb/log"
+
+	swaggermodels "github.com/laincloud/entry/server/gen/models"
+	"github.com/laincloud/entry/server/gen/restapi/operations/sessions"
+	"github.com/laincloud/entry/server/global"
+	"github.com/laincloud/entry/server/models"
+	"github.com/laincloud/entry/server/pipe"
+)
+
+// GetSessionScreen return the screen of the session at the offset in its recording, which is emulated by entry
+func GetSessionScreen(params sessions.GetSessionScreenParams, g *global.Global) middleware.Responder {
+	var s models.Session
+	if err := g.DB.Where("session_id = ?", params.SessionID).First(&s).Error; err != nil {
+		errMsg := fmt.Sprintf("Session: %d is not found.", params.SessionID)
+		log.Errorf("g.DB.Where(session_id = %d) failed, error: %s.", params.SessionID, err)
+		return sessions.NewGetSessionScreenNotFound().WithPayload(&swaggermodels.Error{
+			Message: &errMsg,
+		})
+	}
+
+	offset := time.Duration(-1)
+	if params.Offset != nil {
+		offset = time.Duration(*params.Offset) * time.Millisecond
+	}
+	screen, elapsed, err := pipe.ReplayScreen(s, offset, int(*params.Width), int(*params.Height))
+	if err != nil {
+		errMsg := err.Error()
+		log.Errorf("pipe.ReplayScreen() failed, error: %s, session: %+v.", err, s)
+		if os.IsNotExist(err) {
+			errMsg = fmt.Sprintf("The recording of session: %d is not found.", params.SessionID)
+			return sessions.NewGetSessionScreenNotFound().WithPayload(&swaggermodels.Error{
+				Message: &errMsg,
+			})
+		}
+
+		return sessions.NewGetSessionScreenDefault(http.StatusInternalServerError).WithPayload(&swaggermodels.Error{
+			Message: &errMsg,
+		})
+	}
+
+	row, col := screen.Cursor()
+	width, height := screen.Size()
+	return sessions.NewGetSessionScreenOK().WithPayload(&swaggermodels.Screen{
+		Content:   strings.Join(screen.Lines(), "\n"),
+		CursorRow: int64(row),
+		CursorCol: int64(col),
+		Width:     int64(width),
+		Height:    int64(height),
+		Offset:    int64(elapsed / time.Millisecond),
+	})
+}
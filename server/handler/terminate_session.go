@@ -0,0 +1,80 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/mijia/sweb/log"
+
+	swaggermodels "github.com/laincloud/entry/server/gen/models"
+	"github.com/laincloud/entry/server/gen/restapi/operations/sessions"
+	"github.com/laincloud/entry/server/global"
+	"github.com/laincloud/entry/server/models"
+	"github.com/laincloud/entry/server/pipe"
+)
+
+const defaultTerminateReason = "terminated by entry owners"
+
+// TerminateSession kick the user of the active session out of the container
+func TerminateSession(params sessions.TerminateSessionParams, g *global.Global) middleware.Responder {
+	user, ok := authenticatedUser(params.HTTPRequest)
+	if !ok {
+		errMsg := "The user is not authenticated."
+		return sessions.NewTerminateSessionDefault(http.StatusUnauthorized).WithPayload(&swaggermodels.Error{
+			Message: &errMsg,
+		})
+	}
+
+	var s models.Session
+	if err := g.DB.Where("session_id = ? AND status = ?", params.SessionID, models.SessionStatusActive).First(&s).Error; err != nil {
+		errMsg := fmt.Sprintf("Session: %d is not active.", params.SessionID)
+		log.Errorf("g.DB.Where(session_id = %d) failed, error: %s.", params.SessionID, err)
+		return sessions.NewTerminateSessionNotFound().WithPayload(&swaggermodels.Error{
+			Message: &errMsg,
+		})
+	}
+
+	if s.ParentID != 0 {
+		errMsg := fmt.Sprintf("Session: %d is a part of session: %d, terminate that session instead.", s.SessionID, s.ParentID)
+		log.Errorf("Session: %d can't be terminated by itself, session: %+v.", params.SessionID, s)
+		return sessions.NewTerminateSessionConflict().WithPayload(&swaggermodels.Error{
+			Message: &errMsg,
+		})
+	}
+
+	p, ok := pipe.GetLivePipe(params.SessionID)
+	if !ok {
+		errMsg := fmt.Sprintf("Session: %d is not found in this entry instance.", params.SessionID)
+		log.Errorf("pipe.GetLivePipe(%d) failed, session: %+v.", params.SessionID, s)
+		return sessions.NewTerminateSessionNotFound().WithPayload(&swaggermodels.Error{
+			Message: &errMsg,
+		})
+	}
+
+	reason := defaultTerminateReason
+	if params.Reason != nil && *params.Reason != "" {
+		reason = *params.Reason
+	}
+	log.Warnf("%s is terminating session: %+v, reason: %s.", user.Email, s, reason)
+	if err := p.Terminate(reason, g); err != nil {
+		errMsg := err.Error()
+		log.Errorf("p.Terminate() failed, error: %s, session: %+v.", err, s)
+		s.Update(models.Session{
+			TerminatedBy:  user.Email,
+			CleanupStatus: models.CleanupStatusOf(err),
+		}, g)
+		return sessions.NewTerminateSessionDefault(http.StatusInternalServerError).WithPayload(&swaggermodels.Error{
+			Message: &errMsg,
+		})
+	}
+
+	s.Update(models.Session{
+		Status:        models.SessionStatusInactive,
+		TerminatedBy:  user.Email,
+		CleanupStatus: models.CleanupStatusSucceeded,
+		EndedAt:       time.Now(),
+	}, g)
+	return sessions.NewTerminateSessionNoContent()
+}
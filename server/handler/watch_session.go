@@ -0,0 +1,94 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gorilla/websocket"
+	"github.com/mijia/sweb/log"
+
+	"github.com/laincloud/entry/server/global"
+	"github.com/laincloud/entry/server/message"
+	"github.com/laincloud/entry/server/models"
+	"github.com/laincloud/entry/server/pipe"
+	"github.com/laincloud/entry/server/util"
+)
+
+const watchSessionDoneMsg = "\033[32m>>> Session ended.\033[0m"
+
+// WatchSession watch the active session in real time
+func WatchSession(ctx context.Context, conn *websocket.Conn, r *http.Request, g *global.Global) {
+	paths := strings.Split(r.URL.Path, "/")
+	if len(paths) != 5 {
+		log.Errorf("r.URL.Path: %s is invalid.", r.URL.Path)
+		return
+	}
+
+	rawSessionID := paths[3]
+	sessionID, err := strconv.ParseInt(rawSessionID, 10, 64)
+	if err != nil {
+		log.Errorf("strconv.ParseInt(%s, 10, 64) failed, error: %s.", rawSessionID, err)
+		return
+	}
+
+	var s models.Session
+	if err = g.DB.Where("session_id = ?", sessionID).First(&s).Error; err != nil {
+		log.Errorf("g.DB.Where(session_id = %d) failed, error: %s.", sessionID, err)
+		return
+	}
+
+	msgMarshaller := json.Marshal
+	writeLock := &sync.Mutex{}
+	sessionReplay, ok := pipe.GetLiveSessionReplay(sessionID)
+	if !ok {
+		errMsg := fmt.Sprintf(util.ErrMsgTemplate, "Session is not active, please replay it instead.")
+		log.Errorf("Session: %+v is not active.", s)
+		util.SendCloseMessage(conn, []byte(errMsg), msgMarshaller, writeLock)
+		return
+	}
+
+	watcher, err := sessionReplay.Watch()
+	if err != nil {
+		errMsg := fmt.Sprintf(util.ErrMsgTemplate, "Session is not active, please replay it instead.")
+		log.Errorf("sessionReplay.Watch() failed, error: %s, session: %+v.", err, s)
+		util.SendCloseMessage(conn, []byte(errMsg), msgMarshaller, writeLock)
+		return
+	}
+
+	wg := &sync.WaitGroup{}
+	wg.Add(1)
+	p := pipe.NewPipe(conn, msgMarshaller, &s, json.Unmarshal, wg, writeLock)
+	stopSignal := make(chan int)
+	go p.HandleAliveDetection(stopSignal)
+	go p.HandleResponse(message.ResponseMessage_STDOUT, watcher, nil)
+
+	go func() {
+		// Check whether the websocket is closed, observers are read-only
+		for {
+			if _, _, err1 := conn.ReadMessage(); err1 != nil {
+				break
+			}
+		}
+		watcher.Close()
+	}()
+
+	go func() {
+		wg.Wait()
+		util.SendCloseMessage(conn, []byte(watchSessionDoneMsg), msgMarshaller, writeLock)
+		close(stopSignal)
+	}()
+
+	select {
+	case <-ctx.Done():
+		log.Infof("Watching session: %+v canceled.", s)
+	case <-stopSignal:
+		log.Infof("Watching session: %+v done.", s)
+	}
+	watcher.Close()
+	wg.Wait()
+}
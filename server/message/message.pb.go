@@ -11,6 +11,12 @@ It is generated from these files:
 It has these top-level messages:
 	RequestMessage
 	ResponseMessage
+	Hello
+	Ready
+	FileTransfer
+	Stream
+	ExitStatus
+	SessionInfo
 */
 package message
 
@@ -30,17 +36,44 @@ const _ = proto.ProtoPackageIsVersion1
 type RequestMessage_RequestType int32
 
 const (
-	RequestMessage_PLAIN RequestMessage_RequestType = 0
-	RequestMessage_WINCH RequestMessage_RequestType = 1
+	RequestMessage_PLAIN        RequestMessage_RequestType = 0
+	RequestMessage_WINCH        RequestMessage_RequestType = 1
+	RequestMessage_UPLOAD_START RequestMessage_RequestType = 2
+	RequestMessage_UPLOAD_CHUNK RequestMessage_RequestType = 3
+	RequestMessage_UPLOAD_END   RequestMessage_RequestType = 4
+	RequestMessage_DOWNLOAD     RequestMessage_RequestType = 5
+	RequestMessage_STREAM_OPEN  RequestMessage_RequestType = 6
+	RequestMessage_STREAM_DATA  RequestMessage_RequestType = 7
+	RequestMessage_STREAM_CLOSE RequestMessage_RequestType = 8
+	RequestMessage_HELLO        RequestMessage_RequestType = 9
+	RequestMessage_SIGNAL       RequestMessage_RequestType = 10
 )
 
 var RequestMessage_RequestType_name = map[int32]string{
-	0: "PLAIN",
-	1: "WINCH",
+	0:  "PLAIN",
+	1:  "WINCH",
+	2:  "UPLOAD_START",
+	3:  "UPLOAD_CHUNK",
+	4:  "UPLOAD_END",
+	5:  "DOWNLOAD",
+	6:  "STREAM_OPEN",
+	7:  "STREAM_DATA",
+	8:  "STREAM_CLOSE",
+	9:  "HELLO",
+	10: "SIGNAL",
 }
 var RequestMessage_RequestType_value = map[string]int32{
-	"PLAIN": 0,
-	"WINCH": 1,
+	"PLAIN":        0,
+	"WINCH":        1,
+	"UPLOAD_START": 2,
+	"UPLOAD_CHUNK": 3,
+	"UPLOAD_END":   4,
+	"DOWNLOAD":     5,
+	"STREAM_OPEN":  6,
+	"STREAM_DATA":  7,
+	"STREAM_CLOSE": 8,
+	"HELLO":        9,
+	"SIGNAL":       10,
 }
 
 func (x RequestMessage_RequestType) String() string {
@@ -53,23 +86,47 @@ func (RequestMessage_RequestType) EnumDescriptor() ([]byte, []int) {
 type ResponseMessage_ResponseType int32
 
 const (
-	ResponseMessage_STDOUT ResponseMessage_ResponseType = 0
-	ResponseMessage_STDERR ResponseMessage_ResponseType = 1
-	ResponseMessage_CLOSE  ResponseMessage_ResponseType = 2
-	ResponseMessage_PING   ResponseMessage_ResponseType = 3
+	ResponseMessage_STDOUT       ResponseMessage_ResponseType = 0
+	ResponseMessage_STDERR       ResponseMessage_ResponseType = 1
+	ResponseMessage_CLOSE        ResponseMessage_ResponseType = 2
+	ResponseMessage_PING         ResponseMessage_ResponseType = 3
+	ResponseMessage_RESUME_TOKEN ResponseMessage_ResponseType = 4
+	ResponseMessage_FILE_CHUNK   ResponseMessage_ResponseType = 5
+	ResponseMessage_FILE_RESULT  ResponseMessage_ResponseType = 6
+	ResponseMessage_STREAM_DATA  ResponseMessage_ResponseType = 7
+	ResponseMessage_STREAM_CLOSE ResponseMessage_ResponseType = 8
+	ResponseMessage_READY        ResponseMessage_ResponseType = 9
+	ResponseMessage_SESSION_INFO ResponseMessage_ResponseType = 10
+	ResponseMessage_EXIT         ResponseMessage_ResponseType = 11
 )
 
 var ResponseMessage_ResponseType_name = map[int32]string{
-	0: "STDOUT",
-	1: "STDERR",
-	2: "CLOSE",
-	3: "PING",
+	0:  "STDOUT",
+	1:  "STDERR",
+	2:  "CLOSE",
+	3:  "PING",
+	4:  "RESUME_TOKEN",
+	5:  "FILE_CHUNK",
+	6:  "FILE_RESULT",
+	7:  "STREAM_DATA",
+	8:  "STREAM_CLOSE",
+	9:  "READY",
+	10: "SESSION_INFO",
+	11: "EXIT",
 }
 var ResponseMessage_ResponseType_value = map[string]int32{
-	"STDOUT": 0,
-	"STDERR": 1,
-	"CLOSE":  2,
-	"PING":   3,
+	"STDOUT":       0,
+	"STDERR":       1,
+	"CLOSE":        2,
+	"PING":         3,
+	"RESUME_TOKEN": 4,
+	"FILE_CHUNK":   5,
+	"FILE_RESULT":  6,
+	"STREAM_DATA":  7,
+	"STREAM_CLOSE": 8,
+	"READY":        9,
+	"SESSION_INFO": 10,
+	"EXIT":         11,
 }
 
 func (x ResponseMessage_ResponseType) String() string {
@@ -82,6 +139,9 @@ func (ResponseMessage_ResponseType) EnumDescriptor() ([]byte, []int) {
 type RequestMessage struct {
 	MsgType RequestMessage_RequestType `protobuf:"varint,1,opt,name=msgType,enum=message.RequestMessage_RequestType" json:"msgType,omitempty"`
 	Content []byte                     `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	File    *FileTransfer              `protobuf:"bytes,3,opt,name=file" json:"file,omitempty"`
+	Stream  *Stream                    `protobuf:"bytes,4,opt,name=stream" json:"stream,omitempty"`
+	Hello   *Hello                     `protobuf:"bytes,5,opt,name=hello" json:"hello,omitempty"`
 }
 
 func (m *RequestMessage) Reset()                    { *m = RequestMessage{} }
@@ -89,9 +149,36 @@ func (m *RequestMessage) String() string            { return proto.CompactTextSt
 func (*RequestMessage) ProtoMessage()               {}
 func (*RequestMessage) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{0} }
 
+func (m *RequestMessage) GetFile() *FileTransfer {
+	if m != nil {
+		return m.File
+	}
+	return nil
+}
+
+func (m *RequestMessage) GetStream() *Stream {
+	if m != nil {
+		return m.Stream
+	}
+	return nil
+}
+
+func (m *RequestMessage) GetHello() *Hello {
+	if m != nil {
+		return m.Hello
+	}
+	return nil
+}
+
 type ResponseMessage struct {
-	MsgType ResponseMessage_ResponseType `protobuf:"varint,1,opt,name=msgType,enum=message.ResponseMessage_ResponseType" json:"msgType,omitempty"`
-	Content []byte                       `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	MsgType     ResponseMessage_ResponseType `protobuf:"varint,1,opt,name=msgType,enum=message.ResponseMessage_ResponseType" json:"msgType,omitempty"`
+	Content     []byte                       `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	File        *FileTransfer                `protobuf:"bytes,3,opt,name=file" json:"file,omitempty"`
+	Stream      *Stream                      `protobuf:"bytes,4,opt,name=stream" json:"stream,omitempty"`
+	ExitStatus  *ExitStatus                  `protobuf:"bytes,5,opt,name=exitStatus" json:"exitStatus,omitempty"`
+	Ready       *Ready                       `protobuf:"bytes,6,opt,name=ready" json:"ready,omitempty"`
+	SessionInfo *SessionInfo                 `protobuf:"bytes,7,opt,name=sessionInfo" json:"sessionInfo,omitempty"`
+	InstanceNo  string                       `protobuf:"bytes,8,opt,name=instanceNo" json:"instanceNo,omitempty"`
 }
 
 func (m *ResponseMessage) Reset()                    { *m = ResponseMessage{} }
@@ -99,27 +186,180 @@ func (m *ResponseMessage) String() string            { return proto.CompactTextS
 func (*ResponseMessage) ProtoMessage()               {}
 func (*ResponseMessage) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
 
+func (m *ResponseMessage) GetFile() *FileTransfer {
+	if m != nil {
+		return m.File
+	}
+	return nil
+}
+
+func (m *ResponseMessage) GetStream() *Stream {
+	if m != nil {
+		return m.Stream
+	}
+	return nil
+}
+
+func (m *ResponseMessage) GetExitStatus() *ExitStatus {
+	if m != nil {
+		return m.ExitStatus
+	}
+	return nil
+}
+
+func (m *ResponseMessage) GetReady() *Ready {
+	if m != nil {
+		return m.Ready
+	}
+	return nil
+}
+
+func (m *ResponseMessage) GetSessionInfo() *SessionInfo {
+	if m != nil {
+		return m.SessionInfo
+	}
+	return nil
+}
+
+type Hello struct {
+	Version  int32    `protobuf:"varint,1,opt,name=version" json:"version,omitempty"`
+	Encoding string   `protobuf:"bytes,2,opt,name=encoding" json:"encoding,omitempty"`
+	Width    int32    `protobuf:"varint,3,opt,name=width" json:"width,omitempty"`
+	Height   int32    `protobuf:"varint,4,opt,name=height" json:"height,omitempty"`
+	Features []string `protobuf:"bytes,5,rep,name=features" json:"features,omitempty"`
+}
+
+func (m *Hello) Reset()                    { *m = Hello{} }
+func (m *Hello) String() string            { return proto.CompactTextString(m) }
+func (*Hello) ProtoMessage()               {}
+func (*Hello) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
+
+type Ready struct {
+	Version   int32    `protobuf:"varint,1,opt,name=version" json:"version,omitempty"`
+	SessionID int64    `protobuf:"varint,2,opt,name=sessionID" json:"sessionID,omitempty"`
+	Features  []string `protobuf:"bytes,3,rep,name=features" json:"features,omitempty"`
+	Error     string   `protobuf:"bytes,4,opt,name=error" json:"error,omitempty"`
+}
+
+func (m *Ready) Reset()                    { *m = Ready{} }
+func (m *Ready) String() string            { return proto.CompactTextString(m) }
+func (*Ready) ProtoMessage()               {}
+func (*Ready) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+
+type FileTransfer struct {
+	Path     string `protobuf:"bytes,1,opt,name=path" json:"path,omitempty"`
+	Size     int64  `protobuf:"varint,2,opt,name=size" json:"size,omitempty"`
+	Offset   int64  `protobuf:"varint,3,opt,name=offset" json:"offset,omitempty"`
+	Data     []byte `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"`
+	Checksum string `protobuf:"bytes,5,opt,name=checksum" json:"checksum,omitempty"`
+	Error    string `protobuf:"bytes,6,opt,name=error" json:"error,omitempty"`
+	Mode     uint32 `protobuf:"varint,7,opt,name=mode" json:"mode,omitempty"`
+}
+
+func (m *FileTransfer) Reset()                    { *m = FileTransfer{} }
+func (m *FileTransfer) String() string            { return proto.CompactTextString(m) }
+func (*FileTransfer) ProtoMessage()               {}
+func (*FileTransfer) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+
+type Stream struct {
+	Id    int64  `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
+	Data  []byte `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	Error string `protobuf:"bytes,3,opt,name=error" json:"error,omitempty"`
+}
+
+func (m *Stream) Reset()                    { *m = Stream{} }
+func (m *Stream) String() string            { return proto.CompactTextString(m) }
+func (*Stream) ProtoMessage()               {}
+func (*Stream) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+
+type ExitStatus struct {
+	Code             int64  `protobuf:"varint,1,opt,name=code" json:"code,omitempty"`
+	Reason           string `protobuf:"bytes,2,opt,name=reason" json:"reason,omitempty"`
+	ContainerRunning bool   `protobuf:"varint,3,opt,name=containerRunning" json:"containerRunning,omitempty"`
+}
+
+func (m *ExitStatus) Reset()                    { *m = ExitStatus{} }
+func (m *ExitStatus) String() string            { return proto.CompactTextString(m) }
+func (*ExitStatus) ProtoMessage()               {}
+func (*ExitStatus) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+
+type SessionInfo struct {
+	SessionID   int64  `protobuf:"varint,1,opt,name=sessionID" json:"sessionID,omitempty"`
+	ContainerID string `protobuf:"bytes,2,opt,name=containerID" json:"containerID,omitempty"`
+	NodeIP      string `protobuf:"bytes,3,opt,name=nodeIP" json:"nodeIP,omitempty"`
+	Banner      string `protobuf:"bytes,4,opt,name=banner" json:"banner,omitempty"`
+	InstanceNo  string `protobuf:"bytes,5,opt,name=instanceNo" json:"instanceNo,omitempty"`
+}
+
+func (m *SessionInfo) Reset()                    { *m = SessionInfo{} }
+func (m *SessionInfo) String() string            { return proto.CompactTextString(m) }
+func (*SessionInfo) ProtoMessage()               {}
+func (*SessionInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+
 func init() {
 	proto.RegisterType((*RequestMessage)(nil), "message.RequestMessage")
 	proto.RegisterType((*ResponseMessage)(nil), "message.ResponseMessage")
+	proto.RegisterType((*Hello)(nil), "message.Hello")
+	proto.RegisterType((*Ready)(nil), "message.Ready")
+	proto.RegisterType((*FileTransfer)(nil), "message.FileTransfer")
+	proto.RegisterType((*Stream)(nil), "message.Stream")
+	proto.RegisterType((*ExitStatus)(nil), "message.ExitStatus")
+	proto.RegisterType((*SessionInfo)(nil), "message.SessionInfo")
 	proto.RegisterEnum("message.RequestMessage_RequestType", RequestMessage_RequestType_name, RequestMessage_RequestType_value)
 	proto.RegisterEnum("message.ResponseMessage_ResponseType", ResponseMessage_ResponseType_name, ResponseMessage_ResponseType_value)
 }
 
 var fileDescriptor0 = []byte{
-	// 211 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xe2, 0xe2, 0xcd, 0x4d, 0x2d, 0x2e,
-	0x4e, 0x4c, 0x4f, 0xd5, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x62, 0x87, 0x72, 0x95, 0xfa, 0x18,
-	0xb9, 0xf8, 0x82, 0x52, 0x0b, 0x4b, 0x53, 0x8b, 0x4b, 0x7c, 0x21, 0x42, 0x42, 0xb6, 0x5c, 0xec,
-	0xb9, 0xc5, 0xe9, 0x21, 0x95, 0x05, 0xa9, 0x12, 0x8c, 0x0a, 0x8c, 0x1a, 0x7c, 0x46, 0xca, 0x7a,
-	0x30, 0xcd, 0xa8, 0x2a, 0x61, 0x5c, 0x90, 0xd2, 0x20, 0x98, 0x1e, 0x21, 0x09, 0x2e, 0xf6, 0xe4,
-	0xfc, 0xbc, 0x92, 0xd4, 0xbc, 0x12, 0x09, 0x26, 0xa0, 0x76, 0x9e, 0x20, 0x18, 0x57, 0x49, 0x99,
-	0x8b, 0x1b, 0x49, 0x87, 0x10, 0x27, 0x17, 0x6b, 0x80, 0x8f, 0xa3, 0xa7, 0x9f, 0x00, 0x03, 0x88,
-	0x19, 0xee, 0xe9, 0xe7, 0xec, 0x21, 0xc0, 0xa8, 0xb4, 0x92, 0x91, 0x8b, 0x3f, 0x28, 0xb5, 0xb8,
-	0x20, 0x3f, 0xaf, 0x38, 0x15, 0xe6, 0x22, 0x7b, 0x74, 0x17, 0xa9, 0x22, 0xb9, 0x08, 0x45, 0x29,
-	0x9c, 0x4f, 0xac, 0x9b, 0xac, 0xb9, 0x78, 0x90, 0xb5, 0x08, 0x71, 0x71, 0xb1, 0x05, 0x87, 0xb8,
-	0xf8, 0x87, 0x86, 0x00, 0x5d, 0x05, 0x61, 0xbb, 0x06, 0x05, 0x09, 0x30, 0x82, 0x5c, 0xe8, 0xec,
-	0xe3, 0x1f, 0xec, 0x2a, 0xc0, 0x24, 0xc4, 0xc1, 0xc5, 0x12, 0xe0, 0xe9, 0xe7, 0x2e, 0xc0, 0x9c,
-	0xc4, 0x06, 0x0e, 0x4c, 0x63, 0x40, 0x00, 0x00, 0x00, 0xff, 0xff, 0x8c, 0x8c, 0xcd, 0x07, 0x5d,
-	0x01, 0x00, 0x00,
+	// 805 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xc4, 0x55, 0x4d, 0x8f, 0xe3, 0x44,
+	0x10, 0x5d, 0xc7, 0x71, 0x12, 0x57, 0xb2, 0x99, 0x56, 0xb3, 0x20, 0x0b, 0x21, 0x14, 0x19, 0x10,
+	0x03, 0x87, 0x3d, 0xec, 0x4a, 0xdc, 0x10, 0x0a, 0x13, 0xcf, 0x8e, 0xb5, 0x19, 0x3b, 0x2a, 0x7b,
+	0xb4, 0x70, 0x1a, 0x79, 0xe3, 0xce, 0xc4, 0x62, 0xd2, 0x9e, 0x75, 0x3b, 0xc0, 0xf2, 0x07, 0xf8,
+	0x07, 0xdc, 0xb8, 0x73, 0x42, 0x1c, 0xf9, 0x79, 0xa8, 0xda, 0x1f, 0x71, 0x82, 0x84, 0xb8, 0xed,
+	0xad, 0xde, 0xeb, 0xe7, 0xaa, 0xea, 0xbc, 0xd7, 0x0a, 0x3c, 0xde, 0x09, 0xa5, 0x92, 0x3b, 0xf1,
+	0xf4, 0xa1, 0xc8, 0xcb, 0x9c, 0x0f, 0x6b, 0xe8, 0xfe, 0x66, 0xc2, 0x14, 0xc5, 0x9b, 0xbd, 0x50,
+	0xe5, 0x75, 0x45, 0xf1, 0xaf, 0x61, 0xb8, 0x53, 0x77, 0xf1, 0xdb, 0x07, 0xe1, 0x18, 0x33, 0xe3,
+	0x7c, 0xfa, 0xec, 0x93, 0xa7, 0xcd, 0xc7, 0xc7, 0xca, 0x06, 0x92, 0x14, 0x9b, 0x6f, 0xb8, 0x03,
+	0xc3, 0x75, 0x2e, 0x4b, 0x21, 0x4b, 0xa7, 0x37, 0x33, 0xce, 0x27, 0xd8, 0x40, 0xfe, 0x05, 0xf4,
+	0x37, 0xd9, 0xbd, 0x70, 0xcc, 0x99, 0x71, 0x3e, 0x7e, 0xf6, 0x7e, 0xdb, 0xf5, 0x32, 0xbb, 0x17,
+	0x71, 0x91, 0x48, 0xb5, 0x11, 0x05, 0x6a, 0x09, 0xff, 0x1c, 0x06, 0xaa, 0x2c, 0x44, 0xb2, 0x73,
+	0xfa, 0x5a, 0x7c, 0xd6, 0x8a, 0x23, 0x4d, 0x63, 0x7d, 0xcc, 0x3f, 0x05, 0x6b, 0x2b, 0xee, 0xef,
+	0x73, 0xc7, 0xd2, 0xba, 0x69, 0xab, 0xbb, 0x22, 0x16, 0xab, 0x43, 0xf7, 0x2f, 0x03, 0xc6, 0x9d,
+	0x65, 0xb9, 0x0d, 0xd6, 0x6a, 0x39, 0xf7, 0x03, 0xf6, 0x88, 0xca, 0x57, 0x7e, 0x70, 0x71, 0xc5,
+	0x0c, 0xce, 0x60, 0x72, 0xb3, 0x5a, 0x86, 0xf3, 0xc5, 0x6d, 0x14, 0xcf, 0x31, 0x66, 0xbd, 0x0e,
+	0x73, 0x71, 0x75, 0x13, 0xbc, 0x64, 0x26, 0x9f, 0x02, 0xd4, 0x8c, 0x17, 0x2c, 0x58, 0x9f, 0x4f,
+	0x60, 0xb4, 0x08, 0x5f, 0x05, 0xc4, 0x30, 0x8b, 0x9f, 0xc1, 0x38, 0x8a, 0xd1, 0x9b, 0x5f, 0xdf,
+	0x86, 0x2b, 0x2f, 0x60, 0x83, 0x0e, 0xb1, 0x98, 0xc7, 0x73, 0x36, 0xa4, 0x8e, 0x35, 0x71, 0xb1,
+	0x0c, 0x23, 0x8f, 0x8d, 0x68, 0x81, 0x2b, 0x6f, 0xb9, 0x0c, 0x99, 0xcd, 0x01, 0x06, 0x91, 0xff,
+	0x22, 0x98, 0x2f, 0x19, 0xb8, 0x7f, 0xf6, 0xe1, 0x0c, 0x85, 0x7a, 0xc8, 0xa5, 0x12, 0x8d, 0x33,
+	0xdf, 0x9c, 0x3a, 0xf3, 0x59, 0xc7, 0x99, 0x23, 0x69, 0x8b, 0xdf, 0xa5, 0x37, 0xcf, 0x01, 0xc4,
+	0xcf, 0x59, 0x19, 0x95, 0x49, 0xb9, 0x57, 0xb5, 0x41, 0xef, 0xb5, 0x62, 0xaf, 0x3d, 0xc2, 0x8e,
+	0x8c, 0x0c, 0x2d, 0x44, 0x92, 0xbe, 0x75, 0x06, 0x27, 0x86, 0x22, 0xb1, 0x58, 0x1d, 0xf2, 0xaf,
+	0x60, 0xac, 0x84, 0x52, 0x59, 0x2e, 0x7d, 0xb9, 0xc9, 0x9d, 0xa1, 0xd6, 0x3e, 0x39, 0x2c, 0x72,
+	0x38, 0xc3, 0xae, 0x90, 0x7f, 0x0c, 0x90, 0x49, 0x55, 0x26, 0x72, 0x2d, 0x82, 0xdc, 0x19, 0xcd,
+	0x8c, 0x73, 0x1b, 0x3b, 0x8c, 0xfb, 0xb7, 0x01, 0x93, 0xee, 0x4f, 0xa7, 0x2d, 0x89, 0x17, 0xe1,
+	0x4d, 0xcc, 0x1e, 0xd5, 0xb5, 0x87, 0xc8, 0x0c, 0x72, 0xad, 0x32, 0xb0, 0xc7, 0x47, 0xd0, 0x5f,
+	0xf9, 0xc1, 0x0b, 0x66, 0x92, 0xb9, 0xe8, 0x45, 0x37, 0xd7, 0xde, 0x6d, 0x1c, 0xbe, 0xf4, 0x02,
+	0xd6, 0xa7, 0xb8, 0x5c, 0xfa, 0x4b, 0xaf, 0x8e, 0x8f, 0x0e, 0x88, 0xc6, 0x24, 0x5b, 0xc6, 0xff,
+	0x3b, 0x20, 0xe8, 0xcd, 0x17, 0xdf, 0x33, 0x5b, 0x1f, 0x7a, 0x51, 0xe4, 0x87, 0xc1, 0xad, 0x1f,
+	0x5c, 0x86, 0x0c, 0x68, 0xb8, 0xf7, 0x9d, 0x1f, 0xb3, 0xb1, 0xfb, 0xab, 0x01, 0x96, 0x0e, 0x3d,
+	0xb9, 0xfc, 0xa3, 0x28, 0xe8, 0xce, 0x3a, 0x26, 0x16, 0x36, 0x90, 0x7f, 0x08, 0x23, 0x21, 0xd7,
+	0x79, 0x9a, 0xc9, 0x3b, 0x1d, 0x00, 0x1b, 0x5b, 0xcc, 0x9f, 0x80, 0xf5, 0x53, 0x96, 0x96, 0x5b,
+	0x1d, 0x01, 0x0b, 0x2b, 0xc0, 0x3f, 0x80, 0xc1, 0x56, 0x64, 0x77, 0xdb, 0x52, 0x9b, 0x6d, 0x61,
+	0x8d, 0xa8, 0xd3, 0x46, 0x24, 0xe5, 0xbe, 0x10, 0xe4, 0xac, 0x49, 0x9d, 0x1a, 0xec, 0xbe, 0x01,
+	0x4b, 0x9b, 0xf5, 0x1f, 0x8b, 0x7c, 0x04, 0x76, 0x63, 0xcb, 0x42, 0x6f, 0x62, 0xe2, 0x81, 0x38,
+	0x6a, 0x6e, 0x1e, 0x37, 0xa7, 0x35, 0x45, 0x51, 0xe4, 0x85, 0xde, 0xc7, 0xc6, 0x0a, 0xb8, 0x7f,
+	0x18, 0x30, 0xe9, 0x46, 0x95, 0x73, 0xe8, 0x3f, 0x24, 0xe5, 0x56, 0xcf, 0xb5, 0x51, 0xd7, 0xc4,
+	0xa9, 0xec, 0x17, 0x51, 0xcf, 0xd3, 0x35, 0xdd, 0x2f, 0xdf, 0x6c, 0x94, 0x28, 0xf5, 0xb5, 0x4d,
+	0xac, 0x11, 0x69, 0xd3, 0xa4, 0x4c, 0xf4, 0x94, 0x09, 0xea, 0x9a, 0xd6, 0x5a, 0x6f, 0xc5, 0xfa,
+	0x07, 0xb5, 0xdf, 0xe9, 0x34, 0xdb, 0xd8, 0xe2, 0xc3, 0x5a, 0x83, 0xce, 0x5a, 0xd4, 0x65, 0x97,
+	0xa7, 0x42, 0xe7, 0xf3, 0x31, 0xea, 0xda, 0xfd, 0x16, 0x06, 0xd5, 0x3b, 0xe1, 0x53, 0xe8, 0x65,
+	0xa9, 0xde, 0xd0, 0xc4, 0x5e, 0x96, 0xb6, 0x33, 0x7b, 0x9d, 0x99, 0x6d, 0x5f, 0xb3, 0x7b, 0xdd,
+	0x14, 0xe0, 0xf0, 0x7c, 0xe8, 0xbb, 0x35, 0x4d, 0xa9, 0x3a, 0xe9, 0x9a, 0xee, 0x55, 0x88, 0x44,
+	0xe5, 0xb2, 0xf6, 0xb9, 0x46, 0xfc, 0x4b, 0x60, 0xf4, 0xe4, 0x93, 0x4c, 0x8a, 0x02, 0xf7, 0x52,
+	0x52, 0x12, 0xa8, 0xf5, 0x08, 0xff, 0xc5, 0xbb, 0xbf, 0x1b, 0x30, 0xee, 0xbc, 0xa4, 0x63, 0xd3,
+	0x8c, 0x53, 0xd3, 0x66, 0x30, 0x6e, 0x3b, 0xd4, 0xa6, 0xda, 0xd8, 0xa5, 0x68, 0x27, 0x99, 0xa7,
+	0xc2, 0x5f, 0xd5, 0x97, 0xa9, 0x11, 0xf1, 0xaf, 0x13, 0x29, 0x45, 0xe3, 0x69, 0x8d, 0x4e, 0x1e,
+	0xab, 0x75, 0xfa, 0x58, 0x5f, 0x0f, 0xf4, 0x7f, 0xd9, 0xf3, 0x7f, 0x06, 0x00, 0x5c, 0x95, 0x23,
+	0x44, 0xdc, 0x06, 0x00, 0x00,
 }
@@ -7,6 +7,8 @@ import (
 	"time"
 
 	swaggermodels "github.com/laincloud/entry/server/gen/models"
+	"github.com/mijia/sweb/log"
+
 	"github.com/laincloud/entry/server/global"
 	"github.com/laincloud/entry/server/util"
 )
@@ -133,13 +135,19 @@ var (
 	}
 )
 
-// Command denotes the command typed by user
+// Command denotes the command typed by user, the work directory, exit code and duration are only known
+// if it is captured by the shell hook
 type Command struct {
 	CommandID int64   `gorm:"primary_key"`
 	Session   Session `gorm:"foreignkey:SessionID;association_foreignkey:SessionID"`
 	SessionID int64
 	User      string `gorm:"index"`
 	Content   string
+	WorkDir   string
+	ExitCode  int
+	Duration  int64 // unit: millisecond
+	Captured  bool
+	Pasted    bool
 	CreatedAt time.Time `sql:"not null;DEFAULT:current_timestamp"`
 }
 
@@ -152,11 +160,29 @@ func (c Command) SwaggerModel() swaggermodels.Command {
 		ProcName:   c.Session.ProcName,
 		InstanceNo: c.Session.InstanceNo,
 		Content:    c.Content,
+		WorkDir:    c.WorkDir,
+		ExitCode:   int64(c.ExitCode),
+		Duration:   c.Duration,
+		Captured:   c.Captured,
+		Pasted:     c.Pasted,
 		SessionID:  c.SessionID,
 		CreatedAt:  c.CreatedAt.Unix(),
 	}
 }
 
+// SaveExit save the exit code and the duration of the command captured by the shell hook
+func (c *Command) SaveExit(exitCode int, duration time.Duration, g *global.Global) {
+	c.ExitCode = exitCode
+	c.Duration = int64(duration / time.Millisecond)
+	// Updates with a map, since zero values such as exit code 0 are ignored with a struct
+	if err := g.DB.Model(c).Updates(map[string]interface{}{
+		"exit_code": c.ExitCode,
+		"duration":  c.Duration,
+	}).Error; err != nil {
+		log.Errorf("g.DB.Model().Updates() failed, error: %s, command: %+v.", err, c)
+	}
+}
+
 // IsRisky judge whether this command is risky
 func (c Command) IsRisky() bool {
 	return isRisky(c.Content)
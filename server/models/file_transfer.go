@@ -0,0 +1,48 @@
+package models
+
+import (
+	"time"
+
+	swaggermodels "github.com/laincloud/entry/server/gen/models"
+)
+
+const (
+	FileTransferDirectionUpload   = "upload"
+	FileTransferDirectionDownload = "download"
+	FileTransferStatusSucceeded   = "succeeded"
+	FileTransferStatusFailed      = "failed"
+)
+
+// FileTransfer denotes a file uploaded to or downloaded from the container by user
+type FileTransfer struct {
+	FileTransferID int64   `gorm:"primary_key"`
+	Session        Session `gorm:"foreignkey:SessionID;association_foreignkey:SessionID"`
+	SessionID      int64
+	User           string `gorm:"index"`
+	Direction      string
+	Path           string
+	Size           int64
+	Checksum       string
+	Status         string
+	Error          string
+	CreatedAt      time.Time `sql:"not null;DEFAULT:current_timestamp"`
+}
+
+// SwaggerModel return the swagger version
+func (f FileTransfer) SwaggerModel() swaggermodels.FileTransfer {
+	return swaggermodels.FileTransfer{
+		FileTransferID: f.FileTransferID,
+		SessionID:      f.SessionID,
+		User:           f.User,
+		AppName:        f.Session.AppName,
+		ProcName:       f.Session.ProcName,
+		InstanceNo:     f.Session.InstanceNo,
+		Direction:      f.Direction,
+		Path:           f.Path,
+		Size:           f.Size,
+		Checksum:       f.Checksum,
+		Status:         f.Status,
+		Error:          f.Error,
+		CreatedAt:      f.CreatedAt.Unix(),
+	}
+}
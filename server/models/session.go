@@ -2,16 +2,22 @@ package models
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/fsouza/go-dockerclient"
 	"github.com/gorilla/websocket"
 	swaggermodels "github.com/laincloud/entry/server/gen/models"
+	"github.com/laincloud/lainlet/message"
 	"github.com/mijia/sweb/log"
 
 	"github.com/laincloud/entry/server/global"
+	"github.com/laincloud/entry/server/sso"
 	"github.com/laincloud/entry/server/util"
 )
 
@@ -19,35 +25,173 @@ const (
 	entryAppName          = "entry"
 	SessionStatusActive   = "active"
 	SessionStatusInactive = "inactive"
+	SessionTypeEnter      = "enter"
+	SessionTypeForward    = "forward"
+	SessionTypeExec       = "exec"
+	SessionTypeAttach     = "attach"
+	SessionTypeFanout     = "fanout"
 	dataPath              = "/cloud/data/sessions"
+	sessionIDEnv          = "ENTRY_SESSION_ID"
+	execPollInterval      = 500 * time.Millisecond
+	killTimeout           = 3 * time.Second
+	// exitTimeout is how long docker may take to mark the exec whose output has ended as exited
+	exitTimeout      = time.Second
+	exitPollInterval = 50 * time.Millisecond
+	workDirScript    = `cd -- "$1"; exec "$0"`
+	// rcFileEnv is the environment variable which passes the rc file of the hooked bash, since the arguments are
+	// shown by ps, the rc file is removed from the environment before bash runs
+	rcFileEnv = "ENTRY_RCFILE"
+	// hookedShellScript run bash with the rc file given by rcFileEnv, the rc file sources ~/.bashrc by itself
+	hookedShellScript = `rc=$ENTRY_RCFILE; unset ENTRY_RCFILE; [ -z "$1" ] || cd -- "$1"; exec "$0" --rcfile <(printf '%s' "$rc") -i`
+	// sessionRootsScript set $roots to the root processes of the session, which is the process of the exec if $1,
+	// the pid docker reports, belongs to the session, docker reports the pid on the host, so it is only found when
+	// the container shares the pid namespace of the host, otherwise they are the processes marked by the environment
+	// variable of the session whose parents are not marked, the script exits with 3 if nothing is found
+	sessionRootsScript = `marked() { tr '\0' '\n' 2>/dev/null < /proc/$1/environ | grep -qx '%[1]s'; }
+ppid() { set -- $(sed 's/.*) //' /proc/$1/stat 2>/dev/null); echo $2; }
+roots=
+if [ "$1" -gt 0 ] && marked $1; then
+	roots=$1
+else
+	for p in /proc/[0-9]*; do
+		p=${p#/proc/}
+		if marked $p && ! marked $(ppid $p); then roots="$roots $p"; fi
+	done
+fi
+[ -n "$roots" ] || exit 3
+`
+	// foregroundSignalScript signal the foreground process group of the terminal of the session
+	foregroundSignalScript = `for p in $roots; do
+	set -- $(sed 's/.*) //' /proc/$p/stat 2>/dev/null)
+	if [ "${6:-0}" -gt 0 ]; then kill -%[2]s -$6 2>/dev/null; exit 0; fi
+done
+`
+	// treeSignalScript signal the root processes and all of their descendants, which may have dropped the marker by env -i
+	treeSignalScript = `tree=" $roots "
+procs=$(for p in /proc/[0-9]*; do echo ${p#/proc/} $(ppid ${p#/proc/}); done)
+while :; do
+	last=$tree
+	while read p pp; do
+		case $tree in *" $pp "*) case $tree in *" $p "*) ;; *) tree="$tree$p " ;; esac ;; esac
+	done <<EOF
+$procs
+EOF
+	[ "$last" = "$tree" ] && break
+done
+kill -%[2]s $tree 2>/dev/null
+exit 0`
+	// echoScript exits with 4 if the terminal of the session has echo disabled in the canonical mode, which is how
+	// the programs such as sudo and ssh read passwords, readline and full screen programs disable both and echo by
+	// themselves
+	echoScript = `for p in $roots; do
+	tty=$(readlink /proc/$p/fd/0)
+	case $tty in /dev/pts/*) ;; *) continue ;; esac
+	modes=" $(stty -a < $tty | tr ';\n' '  ') "
+	case $modes in *" -echo "*) case $modes in *" icanon "*) exit 4 ;; esac ;; esac
+	exit 0
+done
+exit 3`
+	// noProcessExitCode is the exit code of sessionRootsScript when no process of the session is found
+	noProcessExitCode = 3
+	noEchoExitCode    = 4
+
+	CleanupStatusSucceeded = "succeeded"
+	CleanupStatusFailed    = "failed"
+	CleanupStatusUnknown   = "unknown"
+
+	ExitReasonExited       = "exited"
+	ExitReasonError        = "error"
+	ExitReasonTerminated   = "terminated"
+	ExitReasonDisconnected = "disconnected"
+	ExitReasonCanceled     = "canceled"
+	ExitReasonIdleTimeout  = "idle_timeout"
+	ExitReasonMaxDuration  = "max_duration"
+	UnknownExitCode        = -1
+)
+
+var (
+	// ErrNoShell means none of the shells can be run in the container
+	ErrNoShell = errors.New("no shell is found in the container")
+	// ErrNoProcess means no process of the session is found in the container, so it is unknown whether they are cleaned up
+	ErrNoProcess = errors.New("no process of the session is found in the container")
+
+	defaultShells = []string{"bash", "ash", "sh"}
+
+	containerSignals = map[string]docker.Signal{
+		"INT":  docker.SIGINT,
+		"TERM": docker.SIGTERM,
+		"QUIT": docker.SIGQUIT,
+	}
 )
 
 // Session denotes a user session connected to a container
 type Session struct {
-	SessionID   int64  `gorm:"primary_key"`
-	User        string `gorm:"index"`
-	SourceIP    string `gorm:"index"`
-	AppName     string `gorm:"index"`
-	ProcName    string
-	InstanceNo  string
-	ContainerID string
-	NodeIP      string
-	Status      string
-	CreatedAt   time.Time `sql:"not null;DEFAULT:current_timestamp"`
-	EndedAt     time.Time
-	UpdatedAt   time.Time `sql:"not null;DEFAULT:current_timestamp"`
+	SessionID        int64  `gorm:"primary_key"`
+	ParentID         int64  `gorm:"index"`
+	User             string `gorm:"index"`
+	SourceIP         string `gorm:"index"`
+	AppName          string `gorm:"index"`
+	ProcName         string
+	InstanceNo       string
+	ContainerID      string
+	NodeIP           string
+	Status           string
+	Type             string
+	TargetPort       int
+	Shell            string
+	BytesIn          int64
+	BytesOut         int64
+	ExitCode         int
+	ExitReason       string
+	ContainerRunning bool
+	TerminatedBy     string
+	CleanupStatus    string
+	SecretEntered    bool
+	CreatedAt        time.Time `sql:"not null;DEFAULT:current_timestamp"`
+	EndedAt          time.Time
+	UpdatedAt        time.Time `sql:"not null;DEFAULT:current_timestamp"`
+	ResumeToken      string    `gorm:"-"`
+	Command          []string  `gorm:"-"`
+	WorkDir          string    `gorm:"-"`
+	Interactive      bool      `gorm:"-"`
+	InstancePicked   bool      `gorm:"-"`
+}
+
+// ExitStatus tell why the shell or the command of the session stopped
+type ExitStatus struct {
+	Code             int
+	Reason           string
+	ContainerRunning bool
 }
 
 // NewSession initialize a session
 func NewSession(conn *websocket.Conn, r *http.Request, g *global.Global) (*Session, error) {
+	return newSession(conn, r, true, g)
+}
+
+// NewProcSession initialize a session for the instances of the proc, the instance number is kept as is
+// since it may select instances, and the container is left empty
+func NewProcSession(conn *websocket.Conn, r *http.Request, g *global.Global) (*Session, error) {
+	return newSession(conn, r, false, g)
+}
+
+func newSession(conn *websocket.Conn, r *http.Request, withContainer bool, g *global.Global) (*Session, error) {
 	isViaWeb := r.URL.Query().Get("method") == "web"
-	var accessToken, appName, procName, instanceNo string
+	var accessToken, appName, procName, instanceNo, instancePolicy, containerRef, resumeToken, targetPort, command, shell, workDir, interactive string
 	msgMarshaller, _ := util.GetMarshalers(r)
 	if !isViaWeb {
 		accessToken = r.Header.Get("access-token")
 		appName = r.Header.Get("app-name")
 		procName = r.Header.Get("proc-name")
 		instanceNo = r.Header.Get("instance-no")
+		instancePolicy = r.Header.Get("instance-policy")
+		containerRef = r.Header.Get("container-id")
+		resumeToken = r.Header.Get("resume-token")
+		targetPort = r.Header.Get("target-port")
+		command = r.Header.Get("command")
+		shell = r.Header.Get("shell")
+		workDir = r.Header.Get("work-dir")
+		interactive = r.Header.Get("interactive")
 	} else {
 		_, msgData, err := conn.ReadMessage()
 		if err != nil {
@@ -61,59 +205,536 @@ func NewSession(conn *websocket.Conn, r *http.Request, g *global.Global) (*Sessi
 		appName = msg["app_name"]
 		procName = msg["proc_name"]
 		instanceNo = msg["instance_no"]
+		instancePolicy = msg["instance_policy"]
+		containerRef = msg["container_id"]
+		resumeToken = msg["resume_token"]
+		targetPort = msg["target_port"]
+		command = msg["command"]
+		shell = msg["shell"]
+		workDir = msg["work_dir"]
+		interactive = msg["interactive"]
 	}
 
-	if appName == entryAppName {
-		log.Errorf("appName == %s is not allowed.", entryAppName)
-		return nil, util.ErrAuthFailed
-	}
-
-	log.Infof("A user wants to enter %s[%s-%s]", appName, procName, instanceNo)
 	writeLock := &sync.Mutex{}
-	ssoUser, err := util.AuthContainer(accessToken, appName, g)
-	if err != nil {
-		errMsg := fmt.Sprintf(util.ErrMsgTemplate, "Authorization failed.")
-		log.Errorf("Authorization failed: %s", err.Error())
-		util.SendCloseMessage(conn, []byte(errMsg), msgMarshaller, writeLock)
-		return nil, err
+	isInteractive := interactive == "true"
+	var (
+		ssoUser        *sso.User
+		err            error
+		container      = &message.Container{}
+		instancePicked bool
+	)
+	if containerRef != "" && withContainer {
+		// Only the owners of entry can enter a container by its ID or name, which may belong to any app even entry itself
+		log.Warnf("A user wants to enter container: %s", containerRef)
+		if ssoUser, err = util.AuthAPI(accessToken, g); err != nil {
+			errMsg := fmt.Sprintf(util.ErrMsgTemplate, "Authorization failed.")
+			log.Errorf("Authorization failed: %s", err.Error())
+			util.SendCloseMessage(conn, []byte(errMsg), msgMarshaller, writeLock)
+			return nil, err
+		}
+
+		if appName, procName, instanceNo, container, err = util.ResolveContainer(containerRef, g); err != nil {
+			errMsg := fmt.Sprintf(util.ErrMsgTemplate, "Container is not found.")
+			log.Errorf("Find container %s error: %s", containerRef, err.Error())
+			util.SendCloseMessage(conn, []byte(errMsg), msgMarshaller, writeLock)
+			return nil, err
+		}
+	} else {
+		if appName == entryAppName {
+			log.Errorf("appName == %s is not allowed.", entryAppName)
+			return nil, util.ErrAuthFailed
+		}
+
+		log.Infof("A user wants to enter %s[%s-%s]", appName, procName, instanceNo)
+		authContainer := util.AuthContainer
+		if isInteractive {
+			authContainer = util.AuthContainerAdmin
+		}
+		if ssoUser, err = authContainer(accessToken, appName, g); err != nil {
+			errMsg := fmt.Sprintf(util.ErrMsgTemplate, "Authorization failed.")
+			log.Errorf("Authorization failed: %s", err.Error())
+			util.SendCloseMessage(conn, []byte(errMsg), msgMarshaller, writeLock)
+			return nil, err
+		}
+
+		instancePicked = withContainer && util.IsAnyInstance(instanceNo)
+		if instancePicked {
+			if instanceNo, container, err = util.PickContainer(appName, procName, instancePolicy, g); err != nil {
+				errMsg := fmt.Sprintf(util.ErrMsgTemplate, "No running instance is found.")
+				log.Errorf("Pick container of %s[%s] error: %s", appName, procName, err.Error())
+				util.SendCloseMessage(conn, []byte(errMsg), msgMarshaller, writeLock)
+				return nil, err
+			}
+		} else if withContainer {
+			if container, err = util.GetContainer(appName, procName, instanceNo, g); err != nil {
+				errMsg := fmt.Sprintf(util.ErrMsgTemplate, "Container is not found.")
+				log.Errorf("Find container %s[%s-%s] error: %s", appName, procName, instanceNo, err.Error())
+				util.SendCloseMessage(conn, []byte(errMsg), msgMarshaller, writeLock)
+				return nil, err
+			}
+		}
 	}
 
-	container, err := util.GetContainer(appName, procName, instanceNo, g)
-	if err != nil {
-		errMsg := fmt.Sprintf(util.ErrMsgTemplate, "Container is not found.")
-		log.Errorf("Find container %s[%s-%s] error: %s", appName, procName, instanceNo, err.Error())
-		util.SendCloseMessage(conn, []byte(errMsg), msgMarshaller, writeLock)
-		return nil, err
+	port, _ := strconv.Atoi(targetPort)
+	var argv []string
+	if command != "" {
+		if err = json.Unmarshal([]byte(command), &argv); err != nil {
+			errMsg := fmt.Sprintf(util.ErrMsgTemplate, "command should be a JSON array of strings.")
+			log.Errorf("json.Unmarshal(%s) failed, error: %s.", command, err)
+			util.SendCloseMessage(conn, []byte(errMsg), msgMarshaller, writeLock)
+			return nil, err
+		}
 	}
 
 	s := Session{
-		User:        ssoUser.Email,
-		SourceIP:    util.GetSourceIP(r),
-		AppName:     appName,
-		ProcName:    procName,
-		InstanceNo:  instanceNo,
-		ContainerID: container.Id,
-		NodeIP:      container.NodeIp,
-		Status:      SessionStatusActive,
+		User:           ssoUser.Email,
+		SourceIP:       util.GetSourceIP(r),
+		AppName:        appName,
+		ProcName:       procName,
+		InstanceNo:     instanceNo,
+		ContainerID:    container.Id,
+		NodeIP:         container.NodeIp,
+		Status:         SessionStatusActive,
+		Type:           SessionTypeEnter,
+		TargetPort:     port,
+		Shell:          shell,
+		ResumeToken:    resumeToken,
+		Command:        argv,
+		WorkDir:        workDir,
+		Interactive:    isInteractive,
+		InstancePicked: instancePicked,
 	}
 	log.Infof("A new session: %+v has been created.", s)
 	return &s, nil
 }
 
+// IsEntryApp return whether the session is in the containers of entry itself, which is only allowed to the owners of entry
+func (s Session) IsEntryApp() bool {
+	return s.AppName == entryAppName
+}
+
 // SwaggerModel return the swagger version
 func (s Session) SwaggerModel() swaggermodels.Session {
 	return swaggermodels.Session{
-		SessionID:   s.SessionID,
-		User:        s.User,
-		SourceIP:    s.SourceIP,
-		AppName:     s.AppName,
-		ProcName:    s.ProcName,
-		InstanceNo:  s.InstanceNo,
-		ContainerID: s.ContainerID,
-		NodeIP:      s.NodeIP,
-		Status:      s.Status,
-		CreatedAt:   s.CreatedAt.Unix(),
-		EndedAt:     s.EndedAt.Unix(),
+		SessionID:        s.SessionID,
+		ParentID:         s.ParentID,
+		User:             s.User,
+		SourceIP:         s.SourceIP,
+		AppName:          s.AppName,
+		ProcName:         s.ProcName,
+		InstanceNo:       s.InstanceNo,
+		ContainerID:      s.ContainerID,
+		NodeIP:           s.NodeIP,
+		Status:           s.Status,
+		Type:             s.Type,
+		TargetPort:       int64(s.TargetPort),
+		Shell:            s.Shell,
+		BytesIn:          s.BytesIn,
+		BytesOut:         s.BytesOut,
+		ExitCode:         int64(s.ExitCode),
+		ExitReason:       s.ExitReason,
+		ContainerRunning: s.ContainerRunning,
+		TerminatedBy:     s.TerminatedBy,
+		CleanupStatus:    s.CleanupStatus,
+		SecretEntered:    s.SecretEntered,
+		CreatedAt:        s.CreatedAt.Unix(),
+		EndedAt:          s.EndedAt.Unix(),
+	}
+}
+
+// DetectShell choose the first shell which can be run in the container,
+// the shell requested by the client is tried first, then the default shell of the app, then bash, ash and sh
+func (s Session) DetectShell(appShell string, g *global.Global) (string, error) {
+	return chooseShell(shellCandidates(s.Shell, appShell), func(shell string) (bool, error) {
+		ok, err := s.canRun(shell, g)
+		if err != nil {
+			log.Errorf("s.canRun(%s) failed, error: %s, session: %+v.", shell, err, s)
+		} else if !ok {
+			log.Infof("Shell: %s is not available, session: %+v.", shell, s)
+		}
+		return ok, err
+	})
+}
+
+// chooseShell return the first candidate which can be run, the candidate failed to be tried is skipped as well,
+// the last error is returned if none can be run and some failed to be tried
+func chooseShell(candidates []string, canRun func(shell string) (bool, error)) (string, error) {
+	var lastErr error
+	for _, shell := range candidates {
+		ok, err := canRun(shell)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+
+		if ok {
+			return shell, nil
+		}
+	}
+
+	if lastErr != nil {
+		return "", lastErr
+	}
+	return "", ErrNoShell
+}
+
+func shellCandidates(requested, appShell string) []string {
+	var candidates []string
+	seen := make(map[string]bool)
+	for _, shell := range append([]string{requested, appShell}, defaultShells...) {
+		if shell != "" && !seen[shell] {
+			seen[shell] = true
+			candidates = append(candidates, shell)
+		}
+	}
+	return candidates
+}
+
+// canRun run the shell in the container without relying on any other shell, docker fails the exec if it is not found
+func (s Session) canRun(shell string, g *global.Global) (bool, error) {
+	exec, err := g.DockerClient.CreateExec(docker.CreateExecOptions{
+		Container: s.ContainerID,
+		Cmd:       []string{shell, "-c", "exit 0"},
+	})
+	if err != nil {
+		return false, err
+	}
+
+	if err = g.DockerClient.StartExec(exec.ID, docker.StartExecOptions{}); err != nil {
+		return false, err
+	}
+
+	inspect, err := WaitExecExited(exec.ID, g)
+	if err != nil {
+		return false, err
+	}
+
+	return inspect.ExitCode == 0, nil
+}
+
+// ShellCmd return the command to run the shell of the session in the work directory
+func (s Session) ShellCmd() []string {
+	if s.WorkDir == "" {
+		return []string{s.Shell}
+	}
+
+	return []string{s.Shell, "-c", workDirScript, s.Shell, s.WorkDir}
+}
+
+// HookedShellCmd return the command to run bash of the session in the work directory with the rc file given by
+// HookedShellEnv
+func (s Session) HookedShellCmd() []string {
+	return []string{s.Shell, "-c", hookedShellScript, s.Shell, s.WorkDir}
+}
+
+// HookedShellEnv return the environment variable of the exec which passes the rc file to HookedShellCmd
+func HookedShellEnv(rcFile string) string {
+	return fmt.Sprintf("%s=%s", rcFileEnv, rcFile)
+}
+
+// Env return the environment variable which marks all processes started in the session
+func (s Session) Env() string {
+	return fmt.Sprintf("%s=%d", sessionIDEnv, s.SessionID)
+}
+
+// CommandLine return the command of the session quoted for the shell
+func (s Session) CommandLine() string {
+	args := make([]string, len(s.Command))
+	for i, arg := range s.Command {
+		if arg != "" && strings.IndexFunc(arg, needQuote) < 0 {
+			args[i] = arg
+		} else {
+			args[i] = "'" + strings.Replace(arg, "'", `'\''`, -1) + "'"
+		}
+	}
+	return strings.Join(args, " ")
+}
+
+func needQuote(r rune) bool {
+	return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || strings.ContainsRune("-_./=:,@%+", r))
+}
+
+// Signal send the signal to all processes started in the session inside the container, execID is the exec
+// of the session if any, whose pid helps to find the processes
+func (s Session) Signal(execID, signal string, g *global.Global) error {
+	return s.runSignalScript(execID, sessionRootsScript+treeSignalScript, signal, g)
+}
+
+// SignalForeground send the signal to the foreground processes of the session inside the container, like typing Ctrl-C,
+// or all processes of the session without a terminal
+func (s Session) SignalForeground(execID, signal string, g *global.Global) error {
+	return s.runSignalScript(execID, sessionRootsScript+foregroundSignalScript+treeSignalScript, signal, g)
+}
+
+func (s Session) runSignalScript(execID, script, signal string, g *global.Global) error {
+	exitCode, err := s.runSessionScript(execID, fmt.Sprintf(script, s.Env(), signal), g)
+	if err != nil {
+		return err
+	}
+
+	switch exitCode {
+	case 0:
+		return nil
+	case noProcessExitCode:
+		return ErrNoProcess
+	default:
+		return fmt.Errorf("signal script exited with code %d", exitCode)
+	}
+}
+
+// EchoDisabled test whether the terminal of the session has echo disabled to read a password
+func (s Session) EchoDisabled(execID string, g *global.Global) (bool, error) {
+	exitCode, err := s.runSessionScript(execID, fmt.Sprintf(sessionRootsScript+echoScript, s.Env()), g)
+	if err != nil {
+		return false, err
+	}
+
+	switch exitCode {
+	case 0:
+		return false, nil
+	case noEchoExitCode:
+		return true, nil
+	case noProcessExitCode:
+		return false, ErrNoProcess
+	default:
+		return false, fmt.Errorf("echo script exited with code %d", exitCode)
+	}
+}
+
+// runSessionScript run the script in the container, and return its exit code
+func (s Session) runSessionScript(execID, script string, g *global.Global) (int, error) {
+	exec, err := g.DockerClient.CreateExec(docker.CreateExecOptions{
+		Container: s.ContainerID,
+		Cmd:       []string{"sh", "-c", script, "sh", strconv.Itoa(execPid(execID, g))},
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	if err = g.DockerClient.StartExec(exec.ID, docker.StartExecOptions{}); err != nil {
+		return 0, err
+	}
+
+	inspect, err := WaitExecExited(exec.ID, g)
+	if err != nil {
+		return 0, err
+	}
+
+	return inspect.ExitCode, nil
+}
+
+// execPid return the pid of the running exec reported by docker, or 0 if it is unknown
+func execPid(execID string, g *global.Global) int {
+	if execID == "" {
+		return 0
+	}
+
+	inspect, err := inspectExecPid(execID, g)
+	if err != nil {
+		log.Errorf("inspectExecPid(%s) failed, error: %s.", execID, err)
+		return 0
+	}
+
+	if !inspect.Running {
+		return 0
+	}
+
+	return inspect.Pid
+}
+
+// execPidInspect is the part of the exec inspection about its process, docker.ExecInspect of the vendored
+// go-dockerclient does not decode the pid
+type execPidInspect struct {
+	Running bool `json:"Running"`
+	Pid     int  `json:"Pid"`
+}
+
+// inspectExecPid inspect the exec by the docker API directly to get its pid
+func inspectExecPid(execID string, g *global.Global) (*execPidInspect, error) {
+	endpoint := g.DockerClient.Endpoint()
+	switch {
+	case strings.HasPrefix(endpoint, "unix://"):
+		// The transport of the client dials the socket, the host is not used
+		endpoint = "http://unix.sock"
+	case strings.HasPrefix(endpoint, "tcp://"):
+		endpoint = "http://" + strings.TrimPrefix(endpoint, "tcp://")
+	case !strings.Contains(endpoint, "://"):
+		endpoint = "http://" + endpoint
+	}
+
+	resp, err := g.DockerClient.HTTPClient.Get(fmt.Sprintf("%s/exec/%s/json", strings.TrimRight(endpoint, "/"), execID))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("docker responded with status %d", resp.StatusCode)
+	}
+
+	var inspect execPidInspect
+	if err = json.NewDecoder(resp.Body).Decode(&inspect); err != nil {
+		return nil, err
+	}
+
+	return &inspect, nil
+}
+
+// SignalContainer send the signal to the main process of the container, which interactive attach sessions talk to
+func (s Session) SignalContainer(signal string, g *global.Global) error {
+	sig, ok := containerSignals[signal]
+	if !ok {
+		return fmt.Errorf("signal: %s is not supported", signal)
+	}
+
+	return g.DockerClient.KillContainer(docker.KillContainerOptions{
+		ID:     s.ContainerID,
+		Signal: sig,
+	})
+}
+
+// StopExec send SIGHUP to the shell of the session, and SIGKILL if it is still running after the grace period
+func (s Session) StopExec(execID string, gracePeriod time.Duration, g *global.Global) error {
+	inspect, err := g.DockerClient.InspectExec(execID)
+	if err != nil {
+		return err
+	}
+
+	if !inspect.Running {
+		return nil
+	}
+
+	log.Infof("Exec: %s is still running, will send SIGHUP, session: %+v.", execID, s)
+	if err = s.Signal(execID, "HUP", g); err != nil {
+		return err
+	}
+
+	if stopped, err := waitExec(execID, gracePeriod, g); err != nil || stopped {
+		return err
+	}
+
+	log.Warnf("Exec: %s is still running after %s, will send SIGKILL, session: %+v.", execID, gracePeriod, s)
+	if err = s.Signal(execID, "KILL", g); err != nil {
+		return err
+	}
+
+	stopped, err := waitExec(execID, killTimeout, g)
+	if err != nil {
+		return err
+	}
+
+	if !stopped {
+		return fmt.Errorf("exec: %s is still running after SIGKILL", execID)
+	}
+
+	return nil
+}
+
+func waitExec(execID string, timeout time.Duration, g *global.Global) (bool, error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		inspect, err := g.DockerClient.InspectExec(execID)
+		if err != nil {
+			return false, err
+		}
+
+		if !inspect.Running {
+			return true, nil
+		}
+
+		if time.Now().After(deadline) {
+			return false, nil
+		}
+
+		time.Sleep(execPollInterval)
+	}
+}
+
+// CleanupStatusOf return the cleanup status of the session stopped by StopExec with the error
+func CleanupStatusOf(err error) string {
+	switch err {
+	case nil:
+		return CleanupStatusSucceeded
+	case ErrNoProcess:
+		return CleanupStatusUnknown
+	default:
+		return CleanupStatusFailed
+	}
+}
+
+// WaitExecExited wait a moment until docker marks the exec whose output has ended as exited, and return the inspection
+func WaitExecExited(execID string, g *global.Global) (*docker.ExecInspect, error) {
+	deadline := time.Now().Add(exitTimeout)
+	for {
+		inspect, err := g.DockerClient.InspectExec(execID)
+		if err != nil || !inspect.Running || !time.Now().Before(deadline) {
+			return inspect, err
+		}
+
+		time.Sleep(exitPollInterval)
+	}
+}
+
+// InspectExit inspect the exec and the container after the exec stopped for the reason,
+// execID is empty if the exec failed to be created
+func (s Session) InspectExit(execID, reason string, g *global.Global) ExitStatus {
+	status := ExitStatus{
+		Code:   UnknownExitCode,
+		Reason: reason,
+	}
+	if execID != "" {
+		if inspect, err := WaitExecExited(execID, g); err != nil {
+			log.Errorf("WaitExecExited(%s) failed, error: %s, session: %+v.", execID, err, s)
+		} else if !inspect.Running {
+			status.Code = inspect.ExitCode
+		}
+	}
+
+	if container, err := g.DockerClient.InspectContainer(s.ContainerID); err != nil {
+		log.Errorf("g.DockerClient.InspectContainer(%s) failed, error: %s, session: %+v.", s.ContainerID, err, s)
+	} else {
+		status.ContainerRunning = container.State.Running
+	}
+	return status
+}
+
+// Create insert the session into the database
+func (s *Session) Create(g *global.Global) error {
+	return g.DB.Create(s).Error
+}
+
+// Update save the non-zero fields into the session in the database, the error is logged
+func (s *Session) Update(fields Session, g *global.Global) {
+	if err := g.DB.Model(s).Updates(fields).Error; err != nil {
+		log.Errorf("g.DB.Model().Updates(%+v) failed, error: %s, session: %+v.", fields, err, s)
+	}
+}
+
+// SaveExitStatus persist the exit status of the session
+func (s *Session) SaveExitStatus(status ExitStatus, g *global.Global) {
+	s.ExitCode = status.Code
+	s.ExitReason = status.Reason
+	s.ContainerRunning = status.ContainerRunning
+	// Updates with a map, since zero values such as exit code 0 are ignored with a struct
+	if err := g.DB.Model(s).Updates(map[string]interface{}{
+		"exit_code":         s.ExitCode,
+		"exit_reason":       s.ExitReason,
+		"container_running": s.ContainerRunning,
+	}).Error; err != nil {
+		log.Errorf("g.DB.Model().Updates() failed, error: %s, session: %+v.", err, s)
+	}
+	log.Infof("Session exited with status: %+v, session: %+v.", status, s)
+}
+
+// SaveSecretEntered note that the user entered a secret such as a password in the session, which is not recorded
+func (s *Session) SaveSecretEntered(g *global.Global) {
+	if s.SecretEntered {
+		return
+	}
+
+	s.SecretEntered = true
+	if err := g.DB.Model(s).Update("secret_entered", true).Error; err != nil {
+		log.Errorf("g.DB.Model().Update(secret_entered) failed, error: %s, session: %+v.", err, s)
 	}
 }
 
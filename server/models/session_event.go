@@ -0,0 +1,59 @@
+package models
+
+import (
+	"time"
+
+	"github.com/mijia/sweb/log"
+
+	swaggermodels "github.com/laincloud/entry/server/gen/models"
+	"github.com/laincloud/entry/server/global"
+)
+
+const (
+	SessionEventTypeSignal = "signal"
+)
+
+// SessionEvent denotes an audit event happened in the session besides commands and file transfers
+type SessionEvent struct {
+	EventID   int64   `gorm:"primary_key"`
+	Session   Session `gorm:"foreignkey:SessionID;association_foreignkey:SessionID"`
+	SessionID int64
+	User      string `gorm:"index"`
+	Type      string
+	Content   string
+	Error     string
+	CreatedAt time.Time `sql:"not null;DEFAULT:current_timestamp"`
+}
+
+// RecordEvent save the audit event of the session
+func (s Session) RecordEvent(eventType, content string, err error, g *global.Global) {
+	event := SessionEvent{
+		SessionID: s.SessionID,
+		User:      s.User,
+		Type:      eventType,
+		Content:   content,
+	}
+	if err != nil {
+		event.Error = err.Error()
+	}
+	if err := g.DB.Create(&event).Error; err != nil {
+		log.Errorf("g.DB.Create() failed, error: %s, event: %+v, session: %+v.", err, event, s)
+	}
+	log.Infof("Session event: %+v, session: %+v.", event, s)
+}
+
+// SwaggerModel return the swagger version
+func (e SessionEvent) SwaggerModel() swaggermodels.SessionEvent {
+	return swaggermodels.SessionEvent{
+		EventID:    e.EventID,
+		SessionID:  e.SessionID,
+		User:       e.User,
+		AppName:    e.Session.AppName,
+		ProcName:   e.Session.ProcName,
+		InstanceNo: e.Session.InstanceNo,
+		Type:       e.Type,
+		Content:    e.Content,
+		Error:      e.Error,
+		CreatedAt:  e.CreatedAt.Unix(),
+	}
+}
@@ -0,0 +1,111 @@
+package models
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestCommandLine(t *testing.T) {
+	cases := []struct {
+		in   []string
+		want string
+	}{
+		{
+			in:   []string{"ls", "-l", "/var/log"},
+			want: "ls -l /var/log",
+		},
+		{
+			in:   []string{"sh", "-c", "echo hello > /tmp/a"},
+			want: "sh -c 'echo hello > /tmp/a'",
+		},
+		{
+			in:   []string{"echo", "it's", ""},
+			want: `echo 'it'\''s' ''`,
+		},
+	}
+
+	for _, c := range cases {
+		got := Session{Command: c.in}.CommandLine()
+		if got != c.want {
+			t.Errorf("Session{Command: %q}.CommandLine() == %s, want: %s.", c.in, got, c.want)
+		}
+	}
+}
+
+func TestShellCandidates(t *testing.T) {
+	cases := []struct {
+		requested string
+		appShell  string
+		want      []string
+	}{
+		{
+			want: []string{"bash", "ash", "sh"},
+		},
+		{
+			requested: "/bin/zsh",
+			appShell:  "sh",
+			want:      []string{"/bin/zsh", "sh", "bash", "ash"},
+		},
+		{
+			appShell: "ash",
+			want:     []string{"ash", "bash", "sh"},
+		},
+	}
+
+	for _, c := range cases {
+		got := shellCandidates(c.requested, c.appShell)
+		if !reflect.DeepEqual(got, c.want) {
+			t.Errorf("shellCandidates(%q, %q) == %q, want: %q.", c.requested, c.appShell, got, c.want)
+		}
+	}
+}
+
+func TestChooseShell(t *testing.T) {
+	errExec := errors.New("exec failed")
+	cases := []struct {
+		candidates []string
+		runnable   map[string]bool
+		failed     map[string]bool
+		want       string
+		wantErr    error
+	}{
+		{
+			candidates: []string{"/bin/bash", "sh"},
+			runnable:   map[string]bool{"/bin/bash": true, "sh": true},
+			want:       "/bin/bash",
+		},
+		{
+			candidates: []string{"/bin/bash", "sh"},
+			runnable:   map[string]bool{"sh": true},
+			failed:     map[string]bool{"/bin/bash": true},
+			want:       "sh",
+		},
+		{
+			candidates: []string{"bash", "ash", "sh"},
+			runnable:   map[string]bool{"sh": true},
+			want:       "sh",
+		},
+		{
+			candidates: []string{"bash", "sh"},
+			wantErr:    ErrNoShell,
+		},
+		{
+			candidates: []string{"bash", "sh"},
+			failed:     map[string]bool{"bash": true},
+			wantErr:    errExec,
+		},
+	}
+
+	for _, c := range cases {
+		got, err := chooseShell(c.candidates, func(shell string) (bool, error) {
+			if c.failed[shell] {
+				return false, errExec
+			}
+			return c.runnable[shell], nil
+		})
+		if got != c.want || err != c.wantErr {
+			t.Errorf("chooseShell(%q) == (%s, %v), want: (%s, %v).", c.candidates, got, err, c.want, c.wantErr)
+		}
+	}
+}
@@ -0,0 +1,28 @@
+package pipe
+
+import (
+	"io"
+
+	"github.com/mijia/sweb/log"
+
+	"github.com/laincloud/entry/server/global"
+)
+
+// HandleAttachRequest handle request from the client of an interactive attach session,
+// the input is written to the stdin of the container and recorded just like HandleRequest
+func (p *Pipe) HandleAttachRequest(containerWriter io.WriteCloser, g *global.Global) {
+	p.handleRequests(requestTarget{
+		stdin: containerWriter,
+		resize: func(width, height int) error {
+			p.resizeContainer(width, height, g)
+			return nil
+		},
+	}, g)
+}
+
+// resizeContainer resize the TTY of the container, which fails harmlessly if the container has no TTY
+func (p *Pipe) resizeContainer(width, height int, g *global.Global) {
+	if err := g.DockerClient.ResizeContainerTTY(p.session.ContainerID, height, width); err != nil {
+		log.Errorf("g.DockerClient.ResizeContainerTTY() failed, error: %s, session: %+v.", err, p.session)
+	}
+}
@@ -0,0 +1,116 @@
+package pipe
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/laincloud/entry/server/message"
+	"github.com/laincloud/entry/server/models"
+)
+
+// NewOutputWriter return a writer which send the output of a command to the client as messages of respType,
+// unlike HandleResponse, the output is sent as is since it may not be text
+func (p *Pipe) NewOutputWriter(respType message.ResponseMessage_ResponseType) io.Writer {
+	return &outputWriter{p: p, respType: respType}
+}
+
+// NewInstanceOutputWriter return a writer like NewOutputWriter, the messages are tagged by the instance of the output
+func (p *Pipe) NewInstanceOutputWriter(respType message.ResponseMessage_ResponseType, instanceNo string) io.Writer {
+	return &outputWriter{p: p, respType: respType, instanceNo: instanceNo}
+}
+
+// SendExitStatus close the session with the exit status of the shell or the command
+func (p *Pipe) SendExitStatus(status models.ExitStatus, content []byte) error {
+	return p.send(&message.ResponseMessage{
+		MsgType: message.ResponseMessage_CLOSE,
+		Content: content,
+		ExitStatus: &message.ExitStatus{
+			Code:             int64(status.Code),
+			Reason:           status.Reason,
+			ContainerRunning: status.ContainerRunning,
+		},
+	})
+}
+
+// instanceExit is the exit status of the command on the instance
+type instanceExit struct {
+	instanceNo string
+	status     models.ExitStatus
+}
+
+// HoldInstanceExits make SendInstanceExit hold the exit statuses until HELLO arrives or ReleaseInstanceExits is called,
+// since how they are sent depends on the features of the client
+func (p *Pipe) HoldInstanceExits() {
+	p.lock.Lock()
+	p.holdExits = true
+	p.lock.Unlock()
+}
+
+// ReleaseInstanceExits send the exit statuses held according to the features of the client, and stop holding them
+func (p *Pipe) ReleaseInstanceExits() error {
+	p.lock.Lock()
+	held := p.heldExits
+	p.holdExits, p.heldExits = false, nil
+	p.lock.Unlock()
+
+	var err error
+	for _, e := range held {
+		if err1 := p.sendInstanceExit(e.instanceNo, e.status); err == nil {
+			err = err1
+		}
+	}
+	return err
+}
+
+// SendInstanceExit tell the client the exit status of the command on the instance, the clients without
+// the instance_exit feature get it as a line of STDERR instead
+func (p *Pipe) SendInstanceExit(instanceNo string, status models.ExitStatus) error {
+	p.lock.Lock()
+	if p.holdExits {
+		p.heldExits = append(p.heldExits, instanceExit{instanceNo: instanceNo, status: status})
+		p.lock.Unlock()
+		return nil
+	}
+	p.lock.Unlock()
+
+	return p.sendInstanceExit(instanceNo, status)
+}
+
+func (p *Pipe) sendInstanceExit(instanceNo string, status models.ExitStatus) error {
+	if !p.ClientSupports(FeatureInstanceExit) {
+		return p.send(&message.ResponseMessage{
+			MsgType:    message.ResponseMessage_STDERR,
+			Content:    []byte(fmt.Sprintf("Command exited with code %d (%s).\n", status.Code, status.Reason)),
+			InstanceNo: instanceNo,
+		})
+	}
+
+	return p.send(&message.ResponseMessage{
+		MsgType:    message.ResponseMessage_EXIT,
+		InstanceNo: instanceNo,
+		ExitStatus: &message.ExitStatus{
+			Code:             int64(status.Code),
+			Reason:           status.Reason,
+			ContainerRunning: status.ContainerRunning,
+		},
+	})
+}
+
+type outputWriter struct {
+	p          *Pipe
+	respType   message.ResponseMessage_ResponseType
+	instanceNo string
+}
+
+// Write implement io.Writer
+func (w *outputWriter) Write(data []byte) (int, error) {
+	if err := w.p.send(&message.ResponseMessage{
+		MsgType:    w.respType,
+		Content:    data,
+		InstanceNo: w.instanceNo,
+	}); err != nil {
+		return 0, err
+	}
+
+	return len(data), nil
+}
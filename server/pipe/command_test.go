@@ -0,0 +1,68 @@
+package pipe
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/laincloud/entry/server/message"
+	"github.com/laincloud/entry/server/models"
+)
+
+func TestHoldInstanceExits(t *testing.T) {
+	cases := []struct {
+		hello *message.Hello
+		want  []message.ResponseMessage_ResponseType
+	}{
+		{
+			hello: &message.Hello{Features: []string{FeatureInstanceExit}},
+			want: []message.ResponseMessage_ResponseType{
+				message.ResponseMessage_READY, message.ResponseMessage_EXIT, message.ResponseMessage_EXIT,
+			},
+		},
+		{
+			hello: &message.Hello{},
+			want: []message.ResponseMessage_ResponseType{
+				message.ResponseMessage_READY, message.ResponseMessage_STDERR, message.ResponseMessage_STDERR,
+			},
+		},
+		{
+			want: []message.ResponseMessage_ResponseType{
+				message.ResponseMessage_STDERR, message.ResponseMessage_STDERR,
+			},
+		},
+	}
+
+	for i, c := range cases {
+		var sent []message.ResponseMessage_ResponseType
+		p := NewPipe(nil, nil, &models.Session{SessionID: 1}, nil, &sync.WaitGroup{}, &sync.Mutex{})
+		// The messages are recorded instead of being written to the websocket
+		p.marshal = func(v interface{}) ([]byte, error) {
+			sent = append(sent, v.(*message.ResponseMessage).MsgType)
+			return nil, errors.New("not sent")
+		}
+
+		p.HoldInstanceExits()
+		p.SendInstanceExit("1", models.ExitStatus{Reason: models.ExitReasonExited})
+		if len(sent) != 0 {
+			t.Errorf("case %d: %v is sent before HELLO, want nothing.", i, sent)
+		}
+
+		if c.hello != nil {
+			p.Handshake(c.hello, "")
+		} else {
+			p.ReleaseInstanceExits()
+		}
+		p.SendInstanceExit("2", models.ExitStatus{Reason: models.ExitReasonExited})
+		if len(sent) != len(c.want) {
+			t.Errorf("case %d: %v is sent, want: %v.", i, sent, c.want)
+			continue
+		}
+		for j := range sent {
+			if sent[j] != c.want[j] {
+				t.Errorf("case %d: %v is sent, want: %v.", i, sent, c.want)
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,268 @@
+package pipe
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/laincloud/entry/server/config"
+	"github.com/laincloud/entry/server/models"
+	"github.com/laincloud/entry/server/util"
+)
+
+const (
+	outputBufferSize = 1024 * 1024
+	resumeTokenSize  = 16
+)
+
+var (
+	errExecDone = errors.New("exec has been done")
+
+	resumableExecs = struct {
+		sync.RWMutex
+		m map[string]*Exec
+	}{m: make(map[string]*Exec)}
+)
+
+// Attachment is a websocket connection attached to the exec
+type Attachment struct {
+	Conn      *websocket.Conn
+	Marshal   util.Marshaler
+	UnMarshal util.Unmarshaler
+	WriteLock *sync.Mutex
+	done      chan struct{}
+}
+
+// NewAttachment return an initialized *Attachment
+func NewAttachment(conn *websocket.Conn, marshal util.Marshaler, unMarshal util.Unmarshaler, writeLock *sync.Mutex) *Attachment {
+	return &Attachment{
+		Conn:      conn,
+		Marshal:   marshal,
+		UnMarshal: unMarshal,
+		WriteLock: writeLock,
+		done:      make(chan struct{}),
+	}
+}
+
+// Detach tell the owner of the websocket connection that the exec will not use it any more
+func (a *Attachment) Detach() {
+	close(a.done)
+}
+
+// Exec is a shell in the container, which outlives the websocket connection so that the user can resume it
+type Exec struct {
+	lastInput   int64 // unix nano, accessed atomically
+	ID          string
+	ResumeToken string
+	Session     *models.Session
+	Stdin       io.Writer
+	Stdout      *OutputBuffer
+	Stderr      *OutputBuffer
+	Capturer    CommandCapturer
+	attachments chan *Attachment
+	done        chan struct{}
+	once        sync.Once
+	startedAt   time.Time
+	stopLock    sync.Mutex
+	stopReason  string
+	stopped     chan struct{} // closed when entry has stopped the exec for stopReason
+	cleanup     string        // the cleanup status of stopping the exec
+}
+
+// NewExec return an initialized *Exec, the output of the exec is recorded and buffered until a websocket connection reads it,
+// the input and the output are observed by capturer if it is not nil
+func NewExec(id string, session *models.Session, stdin io.Writer, stdout, stderr io.Reader, sessionReplay *SessionReplay, capturer CommandCapturer) (*Exec, error) {
+	token := make([]byte, resumeTokenSize)
+	if _, err := rand.Read(token); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	e := &Exec{
+		lastInput:   now.UnixNano(),
+		ID:          id,
+		ResumeToken: hex.EncodeToString(token),
+		Session:     session,
+		Stdin:       stdin,
+		Stdout:      newOutputBuffer(),
+		Stderr:      newOutputBuffer(),
+		Capturer:    capturer,
+		attachments: make(chan *Attachment),
+		done:        make(chan struct{}),
+		startedAt:   now,
+		stopped:     make(chan struct{}),
+	}
+	go e.pump(stdout, e.Stdout, sessionReplay, capturer)
+	go e.pump(stderr, e.Stderr, sessionReplay, nil)
+
+	resumableExecs.Lock()
+	resumableExecs.m[e.ResumeToken] = e
+	resumableExecs.Unlock()
+	return e, nil
+}
+
+// GetResumableExec return the *Exec which can be resumed with the token
+func GetResumableExec(resumeToken string) (*Exec, bool) {
+	resumableExecs.RLock()
+	defer resumableExecs.RUnlock()
+	e, ok := resumableExecs.m[resumeToken]
+	return e, ok
+}
+
+// Attachments return the websocket connections which want to resume the exec
+func (e *Exec) Attachments() <-chan *Attachment {
+	return e.attachments
+}
+
+// Resume hand the websocket connection over to the owner of the exec, and block until it is detached
+func (e *Exec) Resume(ctx context.Context, a *Attachment) error {
+	select {
+	case e.attachments <- a:
+	case <-e.done:
+		return errExecDone
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	select {
+	case <-a.done:
+	case <-ctx.Done():
+	}
+	return nil
+}
+
+// Close make the exec not resumable any more
+func (e *Exec) Close() error {
+	resumableExecs.Lock()
+	delete(resumableExecs.m, e.ResumeToken)
+	resumableExecs.Unlock()
+	e.once.Do(func() {
+		close(e.done)
+	})
+	return nil
+}
+
+// NewStdinWriter return a writer of the stdin of the exec, closing it does not close the stdin
+func (e *Exec) NewStdinWriter() io.WriteCloser {
+	return stdinWriter{e}
+}
+
+type stdinWriter struct {
+	e *Exec
+}
+
+// Write implement io.Writer, the input keeps the exec from the idle timeout
+func (w stdinWriter) Write(p []byte) (int, error) {
+	atomic.StoreInt64(&w.e.lastInput, time.Now().UnixNano())
+	if w.e.Capturer != nil {
+		w.e.Capturer.Input(p)
+	}
+	return w.e.Stdin.Write(p)
+}
+
+// Close implement io.Closer, the stdin is kept open for resuming
+func (w stdinWriter) Close() error {
+	return nil
+}
+
+func (e *Exec) pump(src io.Reader, dst *OutputBuffer, sessionReplay *SessionReplay, capturer CommandCapturer) {
+	buf := make([]byte, config.WriteBufferSize)
+	for {
+		n, err := src.Read(buf)
+		// The recording keeps the raw output, including what the capturer removes from the output sent to the user
+		if sessionReplay != nil && n > 0 {
+			sessionReplay.record(buf[:n])
+		}
+		output := buf[:n]
+		if capturer != nil && n > 0 {
+			output = capturer.Output(output)
+		}
+		if len(output) > 0 {
+			dst.write(output)
+		}
+		if err != nil {
+			dst.close(err)
+			return
+		}
+	}
+}
+
+// OutputBuffer keep the output of the exec which has not been sent to the user yet
+type OutputBuffer struct {
+	cond *sync.Cond
+	data []byte
+	err  error
+}
+
+func newOutputBuffer() *OutputBuffer {
+	return &OutputBuffer{
+		cond: sync.NewCond(&sync.Mutex{}),
+	}
+}
+
+// NewReader return a reader of the buffered output, only one reader should be used at a time
+func (b *OutputBuffer) NewReader() io.ReadCloser {
+	return &outputReader{buffer: b}
+}
+
+func (b *OutputBuffer) write(data []byte) {
+	b.cond.L.Lock()
+	defer b.cond.L.Unlock()
+	b.data = append(b.data, data...)
+	if len(b.data) > outputBufferSize {
+		// Nobody is reading, drop the oldest output
+		b.data = append([]byte(nil), b.data[len(b.data)-outputBufferSize:]...)
+	}
+	b.cond.Broadcast()
+}
+
+func (b *OutputBuffer) close(err error) {
+	b.cond.L.Lock()
+	defer b.cond.L.Unlock()
+	b.err = err
+	b.cond.Broadcast()
+}
+
+type outputReader struct {
+	buffer *OutputBuffer
+	closed bool
+}
+
+// Read implement io.Reader, it blocks until there is output or the reader is closed
+func (r *outputReader) Read(p []byte) (int, error) {
+	b := r.buffer
+	b.cond.L.Lock()
+	defer b.cond.L.Unlock()
+	for len(b.data) == 0 && b.err == nil && !r.closed {
+		b.cond.Wait()
+	}
+
+	if r.closed {
+		return 0, io.EOF
+	}
+
+	if len(b.data) == 0 {
+		return 0, b.err
+	}
+
+	n := copy(p, b.data)
+	b.data = b.data[n:]
+	return n, nil
+}
+
+// Close stop reading, the unread output is kept for the next reader
+func (r *outputReader) Close() error {
+	b := r.buffer
+	b.cond.L.Lock()
+	defer b.cond.L.Unlock()
+	r.closed = true
+	b.cond.Broadcast()
+	return nil
+}
@@ -0,0 +1,47 @@
+package pipe
+
+import (
+	"io"
+	"io/ioutil"
+	"testing"
+)
+
+func TestOutputBufferResume(t *testing.T) {
+	b := newOutputBuffer()
+	b.write([]byte("before "))
+
+	r1 := b.NewReader()
+	buf := make([]byte, 3)
+	if n, err := r1.Read(buf); err != nil || string(buf[:n]) != "bef" {
+		t.Fatalf("r1.Read() == %q, %v, want: %q, nil.", buf[:n], err, "bef")
+	}
+	r1.Close()
+	if n, err := r1.Read(buf); n != 0 || err != io.EOF {
+		t.Errorf("r1.Read() after Close() == %d, %v, want: 0, io.EOF.", n, err)
+	}
+
+	b.write([]byte("after"))
+	b.close(io.EOF)
+	got, err := ioutil.ReadAll(b.NewReader())
+	if err != nil {
+		t.Fatalf("ioutil.ReadAll() failed, error: %s.", err)
+	}
+	if want := "ore after"; string(got) != want {
+		t.Errorf("ioutil.ReadAll() == %q, want: %q.", got, want)
+	}
+}
+
+func TestOutputBufferOverflow(t *testing.T) {
+	b := newOutputBuffer()
+	b.write(make([]byte, outputBufferSize))
+	b.write([]byte("tail"))
+	b.close(io.EOF)
+
+	got, err := ioutil.ReadAll(b.NewReader())
+	if err != nil {
+		t.Fatalf("ioutil.ReadAll() failed, error: %s.", err)
+	}
+	if len(got) != outputBufferSize || string(got[len(got)-4:]) != "tail" {
+		t.Errorf("len(got) == %d, want: %d ending with %q.", len(got), outputBufferSize, "tail")
+	}
+}
@@ -0,0 +1,327 @@
+package pipe
+
+import (
+	"archive/tar"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"sync/atomic"
+	"time"
+
+	"github.com/fsouza/go-dockerclient"
+	"github.com/mijia/sweb/log"
+
+	"github.com/laincloud/entry/server/global"
+	"github.com/laincloud/entry/server/message"
+	"github.com/laincloud/entry/server/models"
+)
+
+const (
+	fileChunkSize = 32 * 1024
+	// defaultFileMode is the mode of the uploaded file if UPLOAD_START doesn't carry one
+	defaultFileMode = 0644
+)
+
+var (
+	errNoUpload         = errors.New("no upload in progress")
+	errUploadAborted    = errors.New("upload aborted because the connection is closed")
+	errUploadInProgress = errors.New("another upload is in progress")
+	errNotRegularFile   = errors.New("not a regular file")
+)
+
+// upload is the file being uploaded, which is buffered in a temporary file until all chunks arrive
+type upload struct {
+	path     string
+	size     int64
+	maxSize  int64 // the limit of the transfer size when the upload started
+	checksum string
+	mode     int64
+	file     *os.File
+	hash     hash.Hash
+	written  int64
+}
+
+func checksum(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func (p *Pipe) handleFileTransfer(msgType message.RequestMessage_RequestType, ft *message.FileTransfer, g *global.Global) {
+	if ft == nil {
+		ft = &message.FileTransfer{}
+	}
+
+	var err error
+	switch msgType {
+	case message.RequestMessage_UPLOAD_START:
+		err = p.startUpload(ft, g.Config.Session.MaxTransferSize())
+	case message.RequestMessage_UPLOAD_CHUNK:
+		err = p.writeUpload(ft)
+	case message.RequestMessage_UPLOAD_END:
+		p.endUpload(g)
+		return
+	case message.RequestMessage_DOWNLOAD:
+		go p.download(ft.Path, g.Config.Session.MaxTransferSize(), g)
+		return
+	}
+
+	if err != nil {
+		log.Errorf("Upload failed, error: %s, session: %+v.", err, p.session)
+		u := p.upload
+		if u == nil {
+			u = &upload{path: ft.Path}
+		}
+		p.upload = nil
+		p.finishUpload(u, err, g)
+	}
+}
+
+// startUpload start uploading the file declared in the header, which can not be larger than maxSize bytes
+func (p *Pipe) startUpload(ft *message.FileTransfer, maxSize int64) error {
+	if p.upload != nil || atomic.LoadInt32(&p.uploading) == 1 {
+		return errUploadInProgress
+	}
+
+	if !path.IsAbs(ft.Path) {
+		return fmt.Errorf("path: %s is not absolute", ft.Path)
+	}
+
+	if ft.Size < 0 || ft.Size > maxSize {
+		return fmt.Errorf("file size: %d is invalid or larger than the limit: %d bytes", ft.Size, maxSize)
+	}
+
+	mode := int64(ft.Mode)
+	if mode == 0 {
+		mode = defaultFileMode
+	} else if mode&^07777 != 0 {
+		return fmt.Errorf("file mode: %o is invalid", mode)
+	}
+
+	f, err := ioutil.TempFile("", "entry-upload-")
+	if err != nil {
+		return err
+	}
+
+	p.upload = &upload{
+		path:     ft.Path,
+		size:     ft.Size,
+		maxSize:  maxSize,
+		checksum: ft.Checksum,
+		mode:     mode,
+		file:     f,
+		hash:     sha256.New(),
+	}
+	log.Infof("Start uploading %s(%d bytes), session: %+v.", ft.Path, ft.Size, p.session)
+	return nil
+}
+
+func (p *Pipe) writeUpload(ft *message.FileTransfer) error {
+	u := p.upload
+	if u == nil {
+		return errNoUpload
+	}
+
+	if ft.Offset != u.written {
+		return fmt.Errorf("chunk offset: %d, want: %d", ft.Offset, u.written)
+	}
+
+	if ft.Checksum != checksum(ft.Data) {
+		return fmt.Errorf("checksum of the chunk at offset: %d mismatch", ft.Offset)
+	}
+
+	if u.written+int64(len(ft.Data)) > u.size {
+		return fmt.Errorf("file is larger than %d bytes", u.size)
+	}
+
+	if u.written+int64(len(ft.Data)) > u.maxSize {
+		return fmt.Errorf("file is larger than the limit: %d bytes", u.maxSize)
+	}
+
+	if _, err := u.file.Write(ft.Data); err != nil {
+		return err
+	}
+
+	u.hash.Write(ft.Data)
+	u.written += int64(len(ft.Data))
+	return nil
+}
+
+// endUpload verify the file and upload it to the container in the background, so that the other messages are
+// handled meanwhile, the result is sent by FILE_RESULT
+func (p *Pipe) endUpload(g *global.Global) {
+	u := p.upload
+	p.upload = nil
+	if u == nil {
+		p.finishUpload(&upload{}, errNoUpload, g)
+		return
+	}
+
+	switch sum := hex.EncodeToString(u.hash.Sum(nil)); {
+	case u.written != u.size:
+		p.finishUpload(u, fmt.Errorf("received %d bytes, want: %d", u.written, u.size), g)
+	case sum != u.checksum:
+		p.finishUpload(u, fmt.Errorf("checksum: %s, want: %s", sum, u.checksum), g)
+	default:
+		atomic.StoreInt32(&p.uploading, 1)
+		go func() {
+			err := p.uploadToContainer(u, g)
+			if err != nil {
+				log.Errorf("p.uploadToContainer(%s) failed, error: %s, session: %+v.", u.path, err, p.session)
+			}
+			atomic.StoreInt32(&p.uploading, 0)
+			p.finishUpload(u, err, g)
+		}()
+	}
+}
+
+func (p *Pipe) uploadToContainer(u *upload, g *global.Global) error {
+	if _, err := u.file.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	pr, pw := io.Pipe()
+	go func() {
+		tw := tar.NewWriter(pw)
+		err := tw.WriteHeader(&tar.Header{
+			Name:    path.Base(u.path),
+			Mode:    u.mode,
+			Size:    u.size,
+			ModTime: time.Now(),
+		})
+		if err == nil {
+			_, err = io.Copy(tw, u.file)
+		}
+		if err == nil {
+			err = tw.Close()
+		}
+		pw.CloseWithError(err)
+	}()
+
+	err := g.DockerClient.UploadToContainer(p.session.ContainerID, docker.UploadToContainerOptions{
+		InputStream:          pr,
+		Path:                 path.Dir(u.path),
+		NoOverwriteDirNonDir: true,
+	})
+	pr.Close()
+	return err
+}
+
+// finishUpload remove the temporary file of the upload, record the upload and send the result
+func (p *Pipe) finishUpload(u *upload, err error, g *global.Global) {
+	if u.file != nil {
+		u.file.Close()
+		if err1 := os.Remove(u.file.Name()); err1 != nil {
+			log.Errorf("os.Remove(%s) failed, error: %s, session: %+v.", u.file.Name(), err1, p.session)
+		}
+	}
+
+	ft := &message.FileTransfer{
+		Path:     u.path,
+		Size:     u.written,
+		Checksum: u.checksum,
+	}
+	if err != nil {
+		ft.Error = err.Error()
+	}
+	p.saveFileTransfer(models.FileTransferDirectionUpload, ft, g)
+	p.sendFileTransfer(message.ResponseMessage_FILE_RESULT, ft)
+}
+
+func (p *Pipe) download(filePath string, maxSize int64, g *global.Global) {
+	ft, err := p.downloadFromContainer(filePath, maxSize, g)
+	if err != nil {
+		log.Errorf("Download failed, error: %s, session: %+v.", err, p.session)
+		ft.Error = err.Error()
+	}
+
+	p.saveFileTransfer(models.FileTransferDirectionDownload, ft, g)
+	p.sendFileTransfer(message.ResponseMessage_FILE_RESULT, ft)
+}
+
+func (p *Pipe) downloadFromContainer(filePath string, maxSize int64, g *global.Global) (*message.FileTransfer, error) {
+	ft := &message.FileTransfer{Path: filePath}
+	pr, pw := io.Pipe()
+	defer pr.Close()
+	go func() {
+		pw.CloseWithError(g.DockerClient.DownloadFromContainer(p.session.ContainerID, docker.DownloadFromContainerOptions{
+			OutputStream: pw,
+			Path:         filePath,
+		}))
+	}()
+
+	tr := tar.NewReader(pr)
+	header, err := tr.Next()
+	if err != nil {
+		return ft, err
+	}
+
+	if header.Typeflag != tar.TypeReg && header.Typeflag != tar.TypeRegA {
+		return ft, errNotRegularFile
+	}
+
+	if header.Size > maxSize {
+		return ft, fmt.Errorf("file size: %d is larger than the limit: %d bytes", header.Size, maxSize)
+	}
+
+	log.Infof("Start downloading %s(%d bytes), session: %+v.", filePath, header.Size, p.session)
+	h := sha256.New()
+	buf := make([]byte, fileChunkSize)
+	for {
+		n, err := io.ReadFull(tr, buf)
+		if n > 0 {
+			h.Write(buf[:n])
+			if err1 := p.sendFileTransfer(message.ResponseMessage_FILE_CHUNK, &message.FileTransfer{
+				Path:     filePath,
+				Size:     header.Size,
+				Offset:   ft.Size,
+				Data:     buf[:n],
+				Checksum: checksum(buf[:n]),
+			}); err1 != nil {
+				return ft, err1
+			}
+			ft.Size += int64(n)
+		}
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			break
+		}
+		if err != nil {
+			return ft, err
+		}
+	}
+
+	ft.Checksum = hex.EncodeToString(h.Sum(nil))
+	return ft, nil
+}
+
+func (p *Pipe) sendFileTransfer(msgType message.ResponseMessage_ResponseType, ft *message.FileTransfer) error {
+	return p.send(&message.ResponseMessage{
+		MsgType: msgType,
+		File:    ft,
+	})
+}
+
+func (p *Pipe) saveFileTransfer(direction string, ft *message.FileTransfer, g *global.Global) {
+	fileTransfer := models.FileTransfer{
+		SessionID: p.session.SessionID,
+		User:      p.session.User,
+		Direction: direction,
+		Path:      ft.Path,
+		Size:      ft.Size,
+		Checksum:  ft.Checksum,
+		Status:    models.FileTransferStatusSucceeded,
+		Error:     ft.Error,
+	}
+	if ft.Error != "" {
+		fileTransfer.Status = models.FileTransferStatusFailed
+	}
+	if err := g.DB.Create(&fileTransfer).Error; err != nil {
+		log.Errorf("g.DB.Create() failed, error: %s, file transfer: %+v, session: %+v.", err, fileTransfer, p.session)
+	}
+	log.Infof("File transfer: %+v, session: %+v.", fileTransfer, p.session)
+}
@@ -0,0 +1,87 @@
+package pipe
+
+import (
+	"os"
+	"testing"
+
+	"github.com/laincloud/entry/server/message"
+)
+
+func TestWriteUpload(t *testing.T) {
+	p := &Pipe{}
+	if err := p.startUpload(&message.FileTransfer{Path: "relative/path", Size: 5}, 5); err == nil {
+		t.Error("p.startUpload() should fail with relative path.")
+	}
+
+	if err := p.startUpload(&message.FileTransfer{Path: "/tmp/hello", Size: 6}, 5); err == nil {
+		t.Error("p.startUpload() should fail with the file larger than the limit.")
+	}
+
+	if err := p.startUpload(&message.FileTransfer{Path: "/tmp/hello", Size: -1}, 5); err == nil {
+		t.Error("p.startUpload() should fail with negative size.")
+	}
+
+	if err := p.startUpload(&message.FileTransfer{Path: "/tmp/hello", Size: 5}, 5); err != nil {
+		t.Fatalf("p.startUpload() failed, error: %s.", err)
+	}
+	defer os.Remove(p.upload.file.Name())
+	defer p.upload.file.Close()
+
+	if err := p.startUpload(&message.FileTransfer{Path: "/tmp/hello", Size: 5}, 5); err != errUploadInProgress {
+		t.Errorf("p.startUpload() == %v, want: %v.", err, errUploadInProgress)
+	}
+
+	cases := []struct {
+		chunk   *message.FileTransfer
+		wantErr bool
+	}{
+		{&message.FileTransfer{Offset: 0, Data: []byte("he"), Checksum: checksum([]byte("he"))}, false},
+		{&message.FileTransfer{Offset: 0, Data: []byte("ll"), Checksum: checksum([]byte("ll"))}, true},
+		{&message.FileTransfer{Offset: 2, Data: []byte("ll"), Checksum: checksum([]byte("xx"))}, true},
+		{&message.FileTransfer{Offset: 2, Data: []byte("llo!"), Checksum: checksum([]byte("llo!"))}, true},
+		{&message.FileTransfer{Offset: 2, Data: []byte("llo"), Checksum: checksum([]byte("llo"))}, false},
+	}
+	for i, c := range cases {
+		if err := p.writeUpload(c.chunk); (err != nil) != c.wantErr {
+			t.Errorf("case %d: p.writeUpload() == %v, wantErr: %v.", i, err, c.wantErr)
+		}
+	}
+	if p.upload.written != 5 {
+		t.Errorf("p.upload.written == %d, want: 5.", p.upload.written)
+	}
+}
+
+func TestStartUploadMode(t *testing.T) {
+	cases := []struct {
+		mode     uint32
+		wantMode int64
+		wantErr  bool
+	}{
+		{0, 0644, false},
+		{0755, 0755, false},
+		{04755, 04755, false},
+		{0100644, 0, true},
+	}
+
+	for _, c := range cases {
+		p := &Pipe{}
+		err := p.startUpload(&message.FileTransfer{Path: "/tmp/hello", Size: 5, Mode: c.mode}, 5)
+		if (err != nil) != c.wantErr {
+			t.Errorf("p.startUpload(mode: %o) == %v, wantErr: %v.", c.mode, err, c.wantErr)
+		}
+		if p.upload == nil {
+			continue
+		}
+
+		p.upload.file.Close()
+		os.Remove(p.upload.file.Name())
+		if p.upload.mode != c.wantMode {
+			t.Errorf("p.startUpload(mode: %o) set the mode to %o, want: %o.", c.mode, p.upload.mode, c.wantMode)
+		}
+	}
+
+	p := &Pipe{uploading: 1}
+	if err := p.startUpload(&message.FileTransfer{Path: "/tmp/hello", Size: 5}, 5); err != errUploadInProgress {
+		t.Errorf("p.startUpload() == %v while the last upload is written to the container, want: %v.", err, errUploadInProgress)
+	}
+}
@@ -0,0 +1,284 @@
+package pipe
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/fsouza/go-dockerclient"
+	"github.com/mijia/sweb/log"
+
+	"github.com/laincloud/entry/server/global"
+	"github.com/laincloud/entry/server/message"
+	"github.com/laincloud/entry/server/models"
+	"github.com/laincloud/entry/server/util"
+)
+
+// forwardScript connect stdin/stdout to the port with whichever tool the container has
+const forwardScript = `if command -v socat >/dev/null 2>&1; then exec socat - TCP:127.0.0.1:%[1]d
+elif command -v nc >/dev/null 2>&1; then exec nc 127.0.0.1 %[1]d
+elif command -v bash >/dev/null 2>&1; then exec bash -c 'exec 3<>/dev/tcp/127.0.0.1/%[1]d && { cat <&3 & cat >&3; wait; }'
+else echo "socat, nc or bash is required to forward ports" >&2; exit 1; fi`
+
+const (
+	// maxForwardStreams is the most streams forwarded over a websocket at the same time, each of them runs a helper
+	// process in the container, the streams opened beyond it are closed with an error
+	maxForwardStreams = 32
+	// forwardStreamBuffer is the most STREAM_DATA messages waiting to be written to a stream, the stream which
+	// can't keep up is closed instead of blocking the other streams
+	forwardStreamBuffer = 16
+)
+
+var errStreamStalled = errors.New("the stream is closed since the port doesn't read the data in time")
+
+// Forwarder tunnel TCP connections to a port inside the container over the websocket
+type Forwarder struct {
+	BytesIn  int64
+	BytesOut int64
+	lock     sync.Mutex
+	p        *Pipe
+	port     int
+	streams  map[int64]*forwardStream
+}
+
+// forwardStream is a stream whose data is written to the helper by its own goroutine
+type forwardStream struct {
+	data        chan []byte
+	stdin       *io.PipeReader
+	inputClosed bool  // guarded by the lock of the Forwarder
+	stalled     int32 // the stream has been closed since its buffer filled
+}
+
+// NewForwarder return an initialized *Forwarder
+func NewForwarder(p *Pipe, port int) *Forwarder {
+	return &Forwarder{
+		p:       p,
+		port:    port,
+		streams: make(map[int64]*forwardStream),
+	}
+}
+
+// Serve handle stream requests from the client until the websocket is closed
+func (f *Forwarder) Serve(g *global.Global) {
+	var (
+		err   error
+		wsMsg []byte
+	)
+	for err == nil {
+		if _, wsMsg, err = f.p.conn.ReadMessage(); err == nil {
+			inMsg := message.RequestMessage{}
+			if unmarshalErr := f.p.unMarshal(wsMsg, &inMsg); unmarshalErr != nil {
+				log.Errorf("Unmarshall request failed, error: %s, session: %+v.", unmarshalErr.Error(), f.p.session)
+				continue
+			}
+
+			if inMsg.MsgType == message.RequestMessage_HELLO {
+				f.p.Handshake(inMsg.Hello, util.DetectEncoding(wsMsg))
+				continue
+			}
+
+			stream := inMsg.GetStream()
+			if stream == nil {
+				continue
+			}
+
+			switch inMsg.MsgType {
+			case message.RequestMessage_STREAM_OPEN:
+				f.open(stream.Id, g)
+			case message.RequestMessage_STREAM_DATA:
+				f.write(stream.Id, stream.Data)
+			case message.RequestMessage_STREAM_CLOSE:
+				f.close(stream.Id)
+			}
+		}
+	}
+	log.Infof("Forwarder.Serve() ended, error: %s, session: %+v.", err, f.p.session)
+
+	f.lock.Lock()
+	for id, st := range f.streams {
+		st.closeInput()
+		delete(f.streams, id)
+	}
+	f.lock.Unlock()
+}
+
+func (f *Forwarder) open(id int64, g *global.Global) {
+	f.lock.Lock()
+	_, ok := f.streams[id]
+	count := len(f.streams)
+	f.lock.Unlock()
+	if ok {
+		f.sendClose(id, fmt.Errorf("stream: %d has been opened", id))
+		return
+	}
+
+	if count >= maxForwardStreams {
+		f.sendClose(id, fmt.Errorf("too many streams, at most %d streams can be forwarded at the same time", maxForwardStreams))
+		return
+	}
+
+	exec, err := g.DockerClient.CreateExec(docker.CreateExecOptions{
+		Container:    f.p.session.ContainerID,
+		AttachStdin:  true,
+		AttachStdout: true,
+		AttachStderr: true,
+		Cmd:          []string{"env", f.p.session.Env(), "sh", "-c", fmt.Sprintf(forwardScript, f.port)},
+	})
+	if err != nil {
+		log.Errorf("Create exec failed, error: %s, session: %+v.", err, f.p.session)
+		f.sendClose(id, err)
+		return
+	}
+
+	stdinPipeReader, stdinPipeWriter := io.Pipe()
+	st := &forwardStream{
+		data:  make(chan []byte, forwardStreamBuffer),
+		stdin: stdinPipeReader,
+	}
+	f.lock.Lock()
+	f.streams[id] = st
+	f.lock.Unlock()
+	log.Infof("Stream: %d to port: %d opened, session: %+v.", id, f.port, f.p.session)
+
+	go func() {
+		for data := range st.data {
+			// The data is dropped once the helper stops reading
+			n, _ := stdinPipeWriter.Write(data)
+			atomic.AddInt64(&f.BytesIn, int64(n))
+		}
+		stdinPipeWriter.Close()
+	}()
+
+	go func() {
+		var stderr bytes.Buffer
+		err := g.DockerClient.StartExec(exec.ID, docker.StartExecOptions{
+			OutputStream: &streamWriter{f: f, id: id, st: st},
+			ErrorStream:  &stderr,
+			InputStream:  stdinPipeReader,
+		})
+		warning := strings.TrimSpace(stderr.String())
+		if warning != "" {
+			// The helpers may warn without failing, the exit code tells whether the stream failed
+			log.Warnf("Stream: %d to port: %d wrote to stderr: %s, session: %+v.", id, f.port, warning, f.p.session)
+		}
+		if err == nil {
+			err = execError(exec.ID, warning, g)
+		}
+		stdinPipeReader.Close()
+
+		f.lock.Lock()
+		if f.streams[id] == st {
+			st.closeInput()
+			delete(f.streams, id)
+		}
+		f.lock.Unlock()
+		if atomic.LoadInt32(&st.stalled) == 0 {
+			f.sendClose(id, err)
+		}
+		log.Infof("Stream: %d to port: %d closed, error: %v, session: %+v.", id, f.port, err, f.p.session)
+	}()
+}
+
+// execError return the error of the exited exec according to its exit code, with the stderr of it as the message
+func execError(execID, stderr string, g *global.Global) error {
+	inspect, err := models.WaitExecExited(execID, g)
+	if err != nil {
+		return err
+	}
+
+	if inspect.Running || inspect.ExitCode == 0 {
+		return nil
+	}
+
+	if stderr == "" {
+		stderr = fmt.Sprintf("exited with code %d", inspect.ExitCode)
+	}
+	return fmt.Errorf("%s", stderr)
+}
+
+// write queue the data to the stream without blocking, the stream is closed if its buffer is full
+func (f *Forwarder) write(id int64, data []byte) {
+	f.lock.Lock()
+	st, ok := f.streams[id]
+	if !ok || st.inputClosed {
+		f.lock.Unlock()
+		return
+	}
+
+	select {
+	case st.data <- data:
+		f.lock.Unlock()
+		return
+	default:
+	}
+
+	atomic.StoreInt32(&st.stalled, 1)
+	st.closeInput()
+	delete(f.streams, id)
+	f.lock.Unlock()
+
+	// The helper fails to read or write once its stdin and output are closed
+	st.stdin.CloseWithError(errStreamStalled)
+	f.sendClose(id, errStreamStalled)
+	log.Warnf("Stream: %d to port: %d stalled, session: %+v.", id, f.port, f.p.session)
+}
+
+// close half-close the stream after the data queued is written, the stream is closed when the connection inside
+// the container is closed
+func (f *Forwarder) close(id int64) {
+	f.lock.Lock()
+	if st, ok := f.streams[id]; ok {
+		st.closeInput()
+	}
+	f.lock.Unlock()
+}
+
+// closeInput close the queue of the data, which should be called with the lock of the Forwarder held
+func (st *forwardStream) closeInput() {
+	if !st.inputClosed {
+		st.inputClosed = true
+		close(st.data)
+	}
+}
+
+func (f *Forwarder) sendClose(id int64, err error) {
+	stream := &message.Stream{Id: id}
+	if err != nil {
+		stream.Error = err.Error()
+	}
+	f.p.send(&message.ResponseMessage{
+		MsgType: message.ResponseMessage_STREAM_CLOSE,
+		Stream:  stream,
+	})
+}
+
+// streamWriter send the output of the stream to the client
+type streamWriter struct {
+	f  *Forwarder
+	id int64
+	st *forwardStream
+}
+
+// Write implement io.Writer
+func (w *streamWriter) Write(data []byte) (int, error) {
+	if atomic.LoadInt32(&w.st.stalled) == 1 {
+		return 0, errStreamStalled
+	}
+
+	if err := w.f.p.send(&message.ResponseMessage{
+		MsgType: message.ResponseMessage_STREAM_DATA,
+		Stream: &message.Stream{
+			Id:   w.id,
+			Data: data,
+		},
+	}); err != nil {
+		return 0, err
+	}
+
+	atomic.AddInt64(&w.f.BytesOut, int64(len(data)))
+	return len(data), nil
+}
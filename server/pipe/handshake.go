@@ -0,0 +1,93 @@
+package pipe
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mijia/sweb/log"
+
+	"github.com/laincloud/entry/server/global"
+	"github.com/laincloud/entry/server/message"
+)
+
+const (
+	// ProtocolVersion is the newest version of the protocol the server speaks
+	ProtocolVersion = 1
+
+	execStartTimeout      = time.Second
+	execStartPollInterval = 50 * time.Millisecond
+
+	// FeatureInstanceExit means the client understands the EXIT of each instance, otherwise the exit status is sent as STDERR
+	FeatureInstanceExit = "instance_exit"
+)
+
+// features are the capabilities the server announces in READY
+var features = []string{
+	"resume",
+	"file_transfer",
+	"port_forwarding",
+	"exit_status",
+	"session_info",
+	"signal",
+	"interactive_attach",
+	FeatureInstanceExit,
+}
+
+// Handshake reply the HELLO of the client with READY
+func (p *Pipe) Handshake(hello *message.Hello, encoding string) error {
+	if hello == nil {
+		hello = &message.Hello{}
+	}
+
+	ready := &message.Ready{
+		Version:   negotiateVersion(hello.Version),
+		SessionID: p.session.SessionID,
+		Features:  features,
+	}
+	if hello.Encoding != "" && hello.Encoding != encoding {
+		ready.Error = fmt.Sprintf("encoding: %s mismatches the encoding of the connection: %s", hello.Encoding, encoding)
+	}
+
+	p.lock.Lock()
+	p.clientFeatures = make(map[string]bool)
+	for _, f := range hello.Features {
+		p.clientFeatures[f] = true
+	}
+	p.lock.Unlock()
+	log.Infof("Handshake with client, hello: %+v, ready: %+v, session: %+v.", hello, ready, p.session)
+	err := p.send(&message.ResponseMessage{
+		MsgType: message.ResponseMessage_READY,
+		Ready:   ready,
+	})
+	if err1 := p.ReleaseInstanceExits(); err == nil {
+		err = err1
+	}
+	return err
+}
+
+// ClientSupports return whether the client announced the feature in HELLO
+func (p *Pipe) ClientSupports(feature string) bool {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return p.clientFeatures[feature]
+}
+
+func negotiateVersion(clientVersion int32) int32 {
+	if clientVersion <= 0 || clientVersion > ProtocolVersion {
+		return ProtocolVersion
+	}
+
+	return clientVersion
+}
+
+// waitExecRunning wait until the exec has started, so that the requests such as resizing will not fail
+func waitExecRunning(execID string, g *global.Global) {
+	deadline := time.Now().Add(execStartTimeout)
+	for time.Now().Before(deadline) {
+		if inspect, err := g.DockerClient.InspectExec(execID); err != nil || inspect.Running {
+			return
+		}
+
+		time.Sleep(execStartPollInterval)
+	}
+}
@@ -0,0 +1,92 @@
+package pipe
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"regexp"
+	"time"
+)
+
+var (
+	// ErrLogsUntilReached means the logs after LogFilter.Until are reached, so the logs should stop
+	ErrLogsUntilReached = errors.New("logs until the given time are all read")
+)
+
+// LogFilter is the server side filter of the container logs, so that chatty containers don't flood the client
+type LogFilter struct {
+	Grep       *regexp.Regexp
+	Until      time.Time
+	Timestamps bool
+}
+
+// DockerTimestamps return whether the logs should be requested with timestamps, which are required by Until
+func (f LogFilter) DockerTimestamps() bool {
+	return f.Timestamps || !f.Until.IsZero()
+}
+
+// NewLogWriter return a writer which write the complete lines of the logs passing the filter to w,
+// every line is prefixed by prefix, Close flushes the last line which is not terminated by a newline
+func NewLogWriter(w io.Writer, filter LogFilter, prefix string) io.WriteCloser {
+	return &logWriter{w: w, filter: filter, prefix: []byte(prefix)}
+}
+
+type logWriter struct {
+	w       io.Writer
+	filter  LogFilter
+	prefix  []byte
+	pending []byte
+}
+
+// Write implement io.Writer
+func (l *logWriter) Write(data []byte) (int, error) {
+	l.pending = append(l.pending, data...)
+	for {
+		i := bytes.IndexByte(l.pending, '\n')
+		if i < 0 {
+			return len(data), nil
+		}
+
+		line := l.pending[:i+1]
+		l.pending = l.pending[i+1:]
+		if err := l.writeLine(line); err != nil {
+			return 0, err
+		}
+	}
+}
+
+// Close implement io.Closer
+func (l *logWriter) Close() error {
+	if len(l.pending) == 0 {
+		return nil
+	}
+
+	line := l.pending
+	l.pending = nil
+	return l.writeLine(line)
+}
+
+func (l *logWriter) writeLine(line []byte) error {
+	content := line
+	if l.filter.DockerTimestamps() {
+		if i := bytes.IndexByte(line, ' '); i > 0 {
+			if t, err := time.Parse(time.RFC3339Nano, string(line[:i])); err == nil {
+				if !l.filter.Until.IsZero() && t.After(l.filter.Until) {
+					return ErrLogsUntilReached
+				}
+
+				content = line[i+1:]
+				if !l.filter.Timestamps {
+					line = content
+				}
+			}
+		}
+	}
+
+	if l.filter.Grep != nil && !l.filter.Grep.Match(content) {
+		return nil
+	}
+
+	_, err := l.w.Write(append(append([]byte(nil), l.prefix...), line...))
+	return err
+}
@@ -0,0 +1,57 @@
+package pipe
+
+import (
+	"bytes"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestLogWriter(t *testing.T) {
+	logs := []string{
+		"2018-01-01T00:00:01.000000001Z GET /ping 200\n2018-01-01T00:00:02Z GET /api",
+		" 500\n2018-01-01T00:00:03Z POST /api 200\n",
+		"2018-01-01T00:00:04Z GET /ping 200",
+	}
+	cases := []struct {
+		filter  LogFilter
+		prefix  string
+		want    string
+		wantErr error
+	}{
+		{
+			filter: LogFilter{Until: time.Date(2018, 1, 1, 0, 0, 5, 0, time.UTC)},
+			want:   "GET /ping 200\nGET /api 500\nPOST /api 200\nGET /ping 200",
+		},
+		{
+			filter: LogFilter{Timestamps: true, Grep: regexp.MustCompile("api")},
+			want:   "2018-01-01T00:00:02Z GET /api 500\n2018-01-01T00:00:03Z POST /api 200\n",
+		},
+		{
+			filter: LogFilter{Until: time.Date(2018, 1, 1, 0, 0, 5, 0, time.UTC), Grep: regexp.MustCompile("ping")},
+			prefix: "[1] ",
+			want:   "[1] GET /ping 200\n[1] GET /ping 200",
+		},
+		{
+			filter:  LogFilter{Until: time.Date(2018, 1, 1, 0, 0, 2, 0, time.UTC), Grep: regexp.MustCompile("^GET")},
+			want:    "GET /ping 200\nGET /api 500\n",
+			wantErr: ErrLogsUntilReached,
+		},
+	}
+	for _, c := range cases {
+		var buf bytes.Buffer
+		w := NewLogWriter(&buf, c.filter, c.prefix)
+		var err error
+		for _, l := range logs {
+			if _, err = w.Write([]byte(l)); err != nil {
+				break
+			}
+		}
+		if err == nil {
+			err = w.Close()
+		}
+		if buf.String() != c.want || err != c.wantErr {
+			t.Errorf("NewLogWriter(%+v, %q) wrote (%q, %v), want: (%q, %v).", c.filter, c.prefix, buf.String(), err, c.want, c.wantErr)
+		}
+	}
+}
@@ -0,0 +1,310 @@
+package pipe
+
+import (
+	"bytes"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/mijia/sweb/log"
+
+	"github.com/laincloud/entry/server/global"
+)
+
+const (
+	maxPromptLength = 256
+	// maxEchoingPrompts limit the prompts remembered to echo the input, such as the prompts of the shell
+	maxEchoingPrompts = 64
+	// maxPendingOutput limit the output kept to find the echo of the pending lines
+	maxPendingOutput = 64 * 1024
+	// echoSettleDelay is how long the output keeps still before the terminal is checked
+	echoSettleDelay = 200 * time.Millisecond
+	// pendingTimeout is how long a pending line waits for its echo before it is taken as a command
+	pendingTimeout = 10 * time.Second
+)
+
+var (
+	// The prompts of sudo, su, passwd, mysql -p, ssh, openssl and so on, after which echo is turned off,
+	// such as "Password:", "Verifying - Enter PEM pass phrase:", "Password for 'https://example.com'?" or "Enter password>"
+	passwordPrompt = regexp.MustCompile(`(?i)\b(password|passwd|pass ?phrase|passcode|pin|secret)\b([^\n]*[:：>?]|)\s*$`)
+	escapeSequence = regexp.MustCompile(`\x1b(\[[0-?]*[ -/]*[@-~]|\][^\a\x1b]*(\a|\x1b\\)|[^\[\]])`)
+)
+
+// echoDetector tell whether the terminal has echo disabled. When the output keeps still, the terminal is checked
+// inside the container if possible, otherwise it is guessed from the output: the programs reading passwords turn off
+// echo after prompting, so the output ends with the prompt until the user presses Enter, and any output after the user
+// typed on the line means echo is on. The prompts whose input was echoed, such as the prompt of the shell, are
+// remembered and never taken as password prompts
+type echoDetector struct {
+	lock      sync.Mutex
+	line      []byte // the last line of the output
+	prompt    string // the last line of the output when the user started typing
+	typed     bool   // the user typed on the line since the last Enter
+	echoed    bool   // the output grew after the user typed, so the prompt may be typed by the user
+	noEcho    bool   // guessed from the output
+	echoing   map[string]bool
+	version   int // counts the output and Enter, after which the terminal may change
+	checked   bool
+	checkedAt int // the version when the terminal was checked
+	ttyNoEcho bool
+	wake      chan struct{}
+}
+
+func newEchoDetector() echoDetector {
+	return echoDetector{wake: make(chan struct{}, 1)}
+}
+
+func (d *echoDetector) output(data []byte) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	if len(data) == 0 {
+		return
+	}
+
+	d.version++
+	if d.typed {
+		d.echoed = true
+	}
+	d.line = append(d.line, data...)
+	if i := bytes.LastIndexAny(d.line, "\r\n"); i >= 0 {
+		d.line = append([]byte(nil), d.line[i+1:]...)
+	}
+	if len(d.line) > maxPromptLength {
+		d.line = d.line[len(d.line)-maxPromptLength:]
+	}
+	prompt := d.cleanLine()
+	d.noEcho = !d.echoed && !d.echoing[string(prompt)] && passwordPrompt.Match(prompt)
+
+	select {
+	case d.wake <- struct{}{}:
+	default:
+	}
+}
+
+// input note the input of the user, which should be called after deciding whether the input is a secret
+func (d *echoDetector) input(data []byte) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	if i := bytes.LastIndexAny(data, "\r\n"); i >= 0 {
+		// The prompt is remembered if the line grew after it as the user typed
+		if line := d.cleanLine(); d.typed && d.echoed && d.prompt != "" && len(line) > len(d.prompt) && bytes.HasPrefix(line, []byte(d.prompt)) {
+			if d.echoing == nil || len(d.echoing) >= maxEchoingPrompts {
+				d.echoing = make(map[string]bool)
+			}
+			d.echoing[d.prompt] = true
+		}
+
+		// A new line starts after Enter, whose prompt is not typed by the user
+		data = data[i+1:]
+		d.typed = false
+		d.echoed = false
+		d.version++
+	}
+	if !d.typed && len(data) > 0 {
+		d.typed = true
+		d.prompt = string(d.cleanLine())
+	}
+}
+
+// disabled test whether the user is typing with echo disabled, such as typing a password
+func (d *echoDetector) disabled() bool {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	if d.checked && d.checkedAt == d.version {
+		return d.ttyNoEcho
+	}
+
+	return d.noEcho
+}
+
+// unknownPrompt test whether the output ends with a line which is not known to echo the input
+func (d *echoDetector) unknownPrompt() bool {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	prompt := d.cleanLine()
+	return len(bytes.TrimSpace(prompt)) > 0 && !d.echoing[string(prompt)]
+}
+
+// settle wait until the output keeps still for echoSettleDelay, it return false if stop is closed
+func (d *echoDetector) settle(stop <-chan struct{}) bool {
+	for {
+		select {
+		case <-stop:
+			return false
+		case <-d.wake:
+		case <-time.After(echoSettleDelay):
+			return true
+		}
+	}
+}
+
+// check test the terminal by check, the result holds until the output or Enter changes the terminal
+func (d *echoDetector) check(check func() (bool, error)) {
+	d.lock.Lock()
+	version := d.version
+	d.lock.Unlock()
+
+	noEcho, err := check()
+	if err != nil {
+		log.Errorf("Checking the echo of the terminal failed, error: %s.", err)
+		return
+	}
+
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	if d.version == version {
+		d.checked = true
+		d.checkedAt = version
+		d.ttyNoEcho = noEcho
+	}
+}
+
+func (d *echoDetector) cleanLine() []byte {
+	return escapeSequence.ReplaceAll(d.line, nil)
+}
+
+// pendingLine is a line entered after another line in one message, which may be read by the program started by the
+// previous line, such as the password of sudo, so whether it is a secret is decided after the output shows
+// whether it is echoed
+type pendingLine struct {
+	keys    []byte
+	echo    []byte // the printable text of the line, which is found in the output if it is echoed
+	pasted  bool
+	entered time.Time
+}
+
+// pendingLines is the queue of the lines to be decided, the lines entered later wait behind them to keep the order
+type pendingLines struct {
+	lock   sync.Mutex
+	lines  []pendingLine
+	output []byte // the output since the first line is pending, without the escape sequences
+	last   []byte // the text of the line entered last
+	skip   []byte // the text of the line before the first pending line, whose echo is not taken as theirs
+	g      *global.Global
+}
+
+// CheckEcho make the pipe test the terminal by check whenever the output keeps still, instead of guessing it from
+// the output only
+func (p *Pipe) CheckEcho(check func() (bool, error)) {
+	p.echoCheck = check
+}
+
+// enterLine record the line accepted by Enter as a command, or note it as a secret if echo is disabled,
+// the line is pending if it follows another line in one message or any line is still pending
+func (p *Pipe) enterLine(keys []byte, pasted, follows bool, g *global.Global) {
+	p.pending.lock.Lock()
+	defer p.pending.lock.Unlock()
+	last := p.pending.last
+	p.pending.last = printable(keys)
+	if !follows && len(p.pending.lines) == 0 {
+		if p.echo.disabled() {
+			// The line typed with echo disabled is a secret such as a password, it is not recorded
+			p.saveSecret(g)
+		} else {
+			p.saveCommand(keys, pasted, g)
+		}
+		return
+	}
+
+	if len(p.pending.lines) == 0 {
+		p.pending.output = nil
+		p.pending.skip = last
+	}
+	p.pending.lines = append(p.pending.lines, pendingLine{
+		keys:    append([]byte(nil), keys...),
+		echo:    printable(keys),
+		pasted:  pasted,
+		entered: time.Now(),
+	})
+	p.pending.g = g
+}
+
+// decidePending decide the pending lines by the output: the line echoed is a command, the line read when the
+// output keeps still with echo disabled is a secret, and the line not echoed for pendingTimeout is taken as a command
+func (p *Pipe) decidePending(output []byte, settled bool) {
+	p.pending.lock.Lock()
+	defer p.pending.lock.Unlock()
+	if len(p.pending.lines) == 0 {
+		return
+	}
+
+	p.pending.output = append(p.pending.output, escapeSequence.ReplaceAll(output, nil)...)
+	if len(p.pending.output) > maxPendingOutput {
+		p.pending.output = p.pending.output[len(p.pending.output)-maxPendingOutput:]
+	}
+	if i := bytes.Index(p.pending.output, p.pending.skip); len(p.pending.skip) > 0 && i >= 0 {
+		p.pending.output = p.pending.output[i+len(p.pending.skip):]
+		p.pending.skip = nil
+	}
+	secretRead := false
+	for len(p.pending.lines) > 0 {
+		l := p.pending.lines[0]
+		if i := bytes.Index(p.pending.output, l.echo); len(l.echo) == 0 || i >= 0 {
+			if len(l.echo) > 0 {
+				p.pending.output = p.pending.output[i+len(l.echo):]
+			}
+			p.saveCommand(l.keys, l.pasted, p.pending.g)
+		} else if noEcho := p.echo.disabled(); settled && noEcho && !secretRead {
+			// Only one line is read with echo disabled until the output changes
+			secretRead = true
+			p.saveSecret(p.pending.g)
+		} else if settled && !noEcho && time.Since(l.entered) > pendingTimeout {
+			p.saveCommand(l.keys, l.pasted, p.pending.g)
+		} else {
+			break
+		}
+		p.pending.lines = p.pending.lines[1:]
+	}
+}
+
+// flushPending record the lines still pending as commands, since nothing shows they are secrets
+func (p *Pipe) flushPending() {
+	p.pending.lock.Lock()
+	defer p.pending.lock.Unlock()
+	for _, l := range p.pending.lines {
+		p.saveCommand(l.keys, l.pasted, p.pending.g)
+	}
+	p.pending.lines = nil
+}
+
+func (p *Pipe) hasPending() bool {
+	p.pending.lock.Lock()
+	defer p.pending.lock.Unlock()
+	return len(p.pending.lines) > 0
+}
+
+// watchEcho check the terminal and decide the pending lines whenever the output keeps still, until stop is closed
+func (p *Pipe) watchEcho(stop <-chan struct{}) {
+	for {
+		var timeout <-chan time.Time
+		if p.hasPending() {
+			timeout = time.After(pendingTimeout)
+		}
+		select {
+		case <-stop:
+			return
+		case <-p.echo.wake:
+		case <-timeout:
+		}
+
+		if !p.echo.settle(stop) {
+			return
+		}
+
+		if p.echoCheck != nil && (p.hasPending() || p.echo.unknownPrompt()) {
+			p.echo.check(p.echoCheck)
+		}
+		p.decidePending(nil, true)
+	}
+}
+
+// printable return the text of the keys without the control characters and the escape sequences
+func printable(keys []byte) []byte {
+	var text []byte
+	for _, b := range escapeSequence.ReplaceAll(keys, nil) {
+		if b >= ' ' && b != 0x7f {
+			text = append(text, b)
+		}
+	}
+	return bytes.TrimSpace(text)
+}
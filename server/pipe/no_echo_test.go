@@ -0,0 +1,151 @@
+package pipe
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/laincloud/entry/server/models"
+)
+
+func TestEchoDetector(t *testing.T) {
+	cases := []struct {
+		outputs []string
+		want    bool
+	}{
+		{[]string{"$ sudo su\r\n", "[sudo] password for alice: "}, true},
+		{[]string{"$ mysql -uroot -p\r\nEnter password: "}, true},
+		{[]string{"alice@10.0.0.1's pass", "word: "}, true},
+		{[]string{"\x1b[1mPassword:\x1b[0m "}, true},
+		{[]string{"Enter passphrase for key '/root/.ssh/id_rsa':"}, true},
+		{[]string{"$ openssl genrsa -aes256\r\nEnter PEM pass phrase:"}, true},
+		{[]string{"Verifying - Enter PEM pass phrase:"}, true},
+		{[]string{"Password for 'https://alice@example.com'? "}, true},
+		{[]string{"Enter password> "}, true},
+		{[]string{"Enter your password "}, true},
+		{[]string{"Password: ", "\r\n"}, false},
+		{[]string{"Enter the PIN length: ", "4"}, false},
+		{[]string{"$ echo password: \r\npassword: \r\n$ "}, false},
+		{[]string{"$ ping example.com: "}, false},
+	}
+
+	for _, c := range cases {
+		var d echoDetector
+		for _, output := range c.outputs {
+			d.output([]byte(output))
+		}
+		if got := d.disabled(); got != c.want {
+			t.Errorf("d.output(%q), d.disabled() == %v, want: %v.", c.outputs, got, c.want)
+		}
+	}
+}
+
+type echoStep struct {
+	input  string
+	output string
+}
+
+func TestEchoDetectorInput(t *testing.T) {
+	cases := []struct {
+		steps []echoStep
+		want  bool
+	}{
+		{
+			// The prompt typed by the user is echoed
+			[]echoStep{{"rm -rf / # password:", "rm -rf / # password:"}},
+			false,
+		},
+		{
+			[]echoStep{{"sudo su", "sudo su"}, {"\r", "\r\n[sudo] password for alice: "}, {"secret", ""}},
+			true,
+		},
+		{
+			[]echoStep{{"mysql -p\r", "mysql -p\r\nEnter password: "}},
+			true,
+		},
+		{
+			// The password prompt stays on the line, but echo is on again since the typed characters are echoed
+			[]echoStep{{"", "Password: "}, {"\r", "\r\n"}, {"", "Password: "}, {"a", "a"}},
+			false,
+		},
+		{
+			// The prompt of the shell which looks like a password prompt is remembered once the input is echoed
+			[]echoStep{{"", "pin> "}, {"ls", "ls"}, {"\r", "\r\na b\r\npin> "}},
+			false,
+		},
+		{
+			// The prompt is not remembered if the typed characters are not shown after it
+			[]echoStep{{"", "Password: "}, {"secret", "\r\nwarning\r\n"}, {"\r", "\r\nPassword: "}},
+			true,
+		},
+	}
+
+	for _, c := range cases {
+		var d echoDetector
+		for _, step := range c.steps {
+			d.input([]byte(step.input))
+			d.output([]byte(step.output))
+		}
+		if got := d.disabled(); got != c.want {
+			t.Errorf("d.input() and d.output() with %q, d.disabled() == %v, want: %v.", c.steps, got, c.want)
+		}
+	}
+}
+
+func TestEchoDetectorCheck(t *testing.T) {
+	cases := []struct {
+		outputs []string
+		noEcho  bool
+		after   string // the output after the check
+		want    bool
+	}{
+		// The prompts the pattern misses are found by the terminal
+		{[]string{"$ sudo su\r\n", "[sudo] Passwort für alice: "}, true, "", true},
+		{[]string{"$ ssh bob@host\r\n", "bob@host's 密码："}, true, "", true},
+		// The prompts the pattern matches are not secret if the terminal echoes
+		{[]string{"pin> "}, false, "", false},
+		{[]string{"$ read -p 'Enter the secret name: ' name\r\n", "Enter the secret name: "}, false, "", false},
+		// The check is stale after the output changes
+		{[]string{"$ sudo su\r\n", "[sudo] Passwort für alice: "}, true, "\r\n# ", false},
+		{[]string{"$ ./login\r\n"}, false, "Password: ", true},
+	}
+
+	for _, c := range cases {
+		d := newEchoDetector()
+		for _, output := range c.outputs {
+			d.output([]byte(output))
+		}
+		d.check(func() (bool, error) {
+			return c.noEcho, nil
+		})
+		d.output([]byte(c.after))
+		if got := d.disabled(); got != c.want {
+			t.Errorf("d.output(%q), d.check() == %v, d.output(%q), d.disabled() == %v, want: %v.", c.outputs, c.noEcho, c.after, got, c.want)
+		}
+	}
+}
+
+func TestWatchEcho(t *testing.T) {
+	p := NewPipe(nil, nil, &models.Session{SessionID: 1}, nil, &sync.WaitGroup{}, &sync.Mutex{})
+	checked := make(chan struct{}, 1)
+	p.CheckEcho(func() (bool, error) {
+		checked <- struct{}{}
+		return true, nil
+	})
+	stop := make(chan struct{})
+	defer close(stop)
+	go p.watchEcho(stop)
+
+	p.echo.output([]byte("[sudo] Passwort für alice: "))
+	select {
+	case <-checked:
+	case <-time.After(10 * echoSettleDelay):
+		t.Fatalf("The terminal is not checked after the output keeps still.")
+	}
+	for i := 0; i < 10 && !p.echo.disabled(); i++ {
+		time.Sleep(echoSettleDelay / 10)
+	}
+	if !p.echo.disabled() {
+		t.Errorf("p.echo.disabled() == false after the terminal is checked, want: true.")
+	}
+}
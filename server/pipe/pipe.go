@@ -2,8 +2,11 @@ package pipe
 
 import (
 	"bytes"
+	"fmt"
 	"io"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/gorilla/websocket"
@@ -22,14 +25,46 @@ const (
 	feedbackTimeout        = 100 * time.Millisecond
 )
 
+// They persist the commands and the secrets entered, tests replace them to run without the database
+var (
+	createCommand = func(command *models.Command, g *global.Global) {
+		if err := g.DB.Create(command).Error; err != nil {
+			log.Errorf("g.DB.Create() failed, error: %s, command: %+v.", err, command)
+		}
+	}
+	saveSecretEntered = func(s *models.Session, g *global.Global) {
+		s.SaveSecretEntered(g)
+	}
+)
+
+var livePipes = struct {
+	sync.RWMutex
+	m map[int64]*Pipe
+}{m: make(map[int64]*Pipe)}
+
 // Pipe is a full duplex channel between the docker container and the terminal
 type Pipe struct {
 	conn           *websocket.Conn
+	execID         string
 	marshal        util.Marshaler
 	requestBuffer  chan []byte
 	responseBuffer chan []byte
 	session        *models.Session
+	capturer       CommandCapturer
+	input          *term.Input
+	lines          term.LineSplitter
+	echo           echoDetector
+	echoCheck      func() (bool, error)
+	pending        pendingLines
+	commands       term.CommandGrouper
+	clientFeatures map[string]bool
+	holdExits      bool
+	heldExits      []instanceExit
+	lock           sync.Mutex
+	terminated     int32
 	unMarshal      util.Unmarshaler
+	upload         *upload
+	uploading      int32 // the uploaded file is being written to the container
 	wg             *sync.WaitGroup
 	writeLock      *sync.Mutex
 }
@@ -42,51 +77,179 @@ func NewPipe(conn *websocket.Conn, marshal util.Marshaler, session *models.Sessi
 		requestBuffer:  make(chan []byte, 1),
 		responseBuffer: make(chan []byte, 1),
 		session:        session,
+		input:          term.NewInput(),
+		echo:           newEchoDetector(),
 		unMarshal:      unMarshal,
 		wg:             wg,
 		writeLock:      writeLock,
 	}
 }
 
+// Register make the pipe of the session findable by GetLivePipe until Unregister is called,
+// execID is empty if the session runs no exec of its own, such as attaching or forwarding ports
+func (p *Pipe) Register(execID string) {
+	p.execID = execID
+	livePipes.Lock()
+	livePipes.m[p.session.SessionID] = p
+	livePipes.Unlock()
+}
+
+// CaptureCommands make the pipe tell capturer the size of the terminal
+func (p *Pipe) CaptureCommands(capturer CommandCapturer) {
+	p.capturer = capturer
+}
+
+// Unregister remove the pipe from the live pipes
+func (p *Pipe) Unregister() {
+	livePipes.Lock()
+	if livePipes.m[p.session.SessionID] == p {
+		delete(livePipes.m, p.session.SessionID)
+	}
+	livePipes.Unlock()
+}
+
+// GetLivePipe return the *Pipe of the active session
+func GetLivePipe(sessionID int64) (*Pipe, bool) {
+	livePipes.RLock()
+	defer livePipes.RUnlock()
+	p, ok := livePipes.m[sessionID]
+	return p, ok
+}
+
+// Terminate kick the user out of the container with the reason
+func (p *Pipe) Terminate(reason string, g *global.Global) error {
+	atomic.StoreInt32(&p.terminated, 1)
+	errMsg := fmt.Sprintf(util.ErrMsgTemplate, fmt.Sprintf("Your session has been terminated: %s", reason))
+	util.SendCloseMessage(p.conn, []byte(errMsg), p.marshal, p.writeLock)
+	if p.execID == "" {
+		// The session ends once the websocket is closed, and stops whatever it started in the container
+		p.conn.Close()
+		return nil
+	}
+
+	return p.session.StopExec(p.execID, g.Config.Session.CleanupGracePeriod(), g)
+}
+
+// Terminated return whether the session has been terminated by entry owners
+func (p *Pipe) Terminated() bool {
+	return atomic.LoadInt32(&p.terminated) == 1
+}
+
+// SendMessage send a message to the client
+func (p *Pipe) SendMessage(msgType message.ResponseMessage_ResponseType, content []byte) error {
+	return p.send(&message.ResponseMessage{
+		MsgType: msgType,
+		Content: content,
+	})
+}
+
+func (p *Pipe) send(msg *message.ResponseMessage) error {
+	data, err := p.marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	p.writeLock.Lock()
+	defer p.writeLock.Unlock()
+	return p.conn.WriteMessage(websocket.BinaryMessage, data)
+}
+
+// requestTarget is what the requests from the client are applied to, the exec of the session or the container attached
+type requestTarget struct {
+	stdin        io.WriteCloser
+	resize       func(width, height int) error
+	fileTransfer bool
+}
+
 // HandleRequest handle request from the client
 func (p *Pipe) HandleRequest(execID string, sessionWriter io.WriteCloser, g *global.Global) {
+	waitExecRunning(execID, g)
+	p.handleRequests(requestTarget{
+		stdin: sessionWriter,
+		resize: func(width, height int) error {
+			p.resizeCapturer(width, height)
+			return g.DockerClient.ResizeExecTTY(execID, height, width)
+		},
+		fileTransfer: true,
+	}, g)
+}
+
+// handleRequests apply the requests from the client to the target until the connection fails,
+// the input is recorded as commands unless they are captured otherwise
+func (p *Pipe) handleRequests(t requestTarget, g *global.Global) {
 	var (
 		err   error
 		wsMsg []byte
 		buf   bytes.Buffer
 	)
-	time.Sleep(time.Second)
-	inMsg := message.RequestMessage{}
+	echoStop := make(chan struct{})
+	go p.watchEcho(echoStop)
 	for err == nil {
-		if _, wsMsg, err = p.conn.ReadMessage(); err == nil {
-			if unmarshalErr := p.unMarshal(wsMsg, &inMsg); unmarshalErr == nil {
-				switch inMsg.MsgType {
-				case message.RequestMessage_PLAIN:
-					if _, err = sessionWriter.Write(inMsg.Content); err != nil {
-						log.Errorf("sessionWriter.Write() failed, inMsg.Content: %s(%v), error: %s, session: %+v.", inMsg.Content, inMsg.Content, err, p.session)
-						continue
-					}
-
-					err = p.handleInput(inMsg.Content, &buf, g)
-				case message.RequestMessage_WINCH:
-					if width, height := util.GetWidthAndHeight(inMsg.Content); width >= 0 && height >= 0 {
-						err = g.DockerClient.ResizeExecTTY(execID, height, width)
-					}
-				}
+		if _, wsMsg, err = p.conn.ReadMessage(); err != nil {
+			break
+		}
 
-			} else {
-				log.Errorf("Unmarshall request failed, error: %s, session: %+v.", unmarshalErr.Error(), p.session)
+		inMsg := message.RequestMessage{}
+		if unmarshalErr := p.unMarshal(wsMsg, &inMsg); unmarshalErr != nil {
+			log.Errorf("Unmarshall request failed, error: %s, session: %+v.", unmarshalErr, p.session)
+			continue
+		}
+
+		switch inMsg.MsgType {
+		case message.RequestMessage_PLAIN:
+			if _, err = t.stdin.Write(inMsg.Content); err != nil {
+				log.Errorf("t.stdin.Write() failed, error: %s, session: %+v.", err, p.session)
+				continue
+			}
+
+			_, hooked := p.capturer.(*ShellHook)
+			switch {
+			case p.capturer == nil || hooked:
+				// The commands are rebuilt from the keystrokes unless they are captured otherwise, the shell hook runs
+				// inside the shell of the user, who can disable it or forge its reports, so the keystrokes are kept too
+				err = p.handleInput(inMsg.Content, &buf, g)
+			case p.echo.disabled() && bytes.ContainsAny(inMsg.Content, "\r\n"):
+				p.saveSecret(g)
+			}
+			p.echo.input(inMsg.Content)
+		case message.RequestMessage_WINCH:
+			if width, height := util.GetWidthAndHeight(inMsg.Content); width >= 0 && height >= 0 {
+				err = t.resize(width, height)
+			}
+		case message.RequestMessage_SIGNAL:
+			if signalErr := p.HandleSignal(string(inMsg.Content), g); signalErr != nil {
+				log.Errorf("p.HandleSignal(%s) failed, error: %s, session: %+v.", inMsg.Content, signalErr, p.session)
+			}
+		case message.RequestMessage_HELLO:
+			if err = p.Handshake(inMsg.Hello, util.DetectEncoding(wsMsg)); err == nil && inMsg.Hello != nil && inMsg.Hello.Width > 0 && inMsg.Hello.Height > 0 {
+				err = t.resize(int(inMsg.Hello.Width), int(inMsg.Hello.Height))
+			}
+		case message.RequestMessage_UPLOAD_START, message.RequestMessage_UPLOAD_CHUNK,
+			message.RequestMessage_UPLOAD_END, message.RequestMessage_DOWNLOAD:
+			if t.fileTransfer {
+				p.handleFileTransfer(inMsg.MsgType, inMsg.File, g)
 			}
 		}
 	}
-	if err != nil {
-		log.Errorf("handleRequest failed, error: %s, session: %+v.", err.Error(), p.session)
+	log.Errorf("handleRequests failed, error: %s, session: %+v.", err, p.session)
+	close(echoStop)
+	p.flushPending()
+
+	if p.upload != nil {
+		p.finishUpload(p.upload, errUploadAborted, g)
+		p.upload = nil
 	}
 
-	sessionWriter.Close()
+	t.stdin.Close()
 	p.wg.Done()
 }
 
+func (p *Pipe) resizeCapturer(width, height int) {
+	if p.capturer != nil {
+		p.capturer.Resize(width, height)
+	}
+}
+
 // HandleResponse handle response from the container
 func (p *Pipe) HandleResponse(respType message.ResponseMessage_ResponseType, sessionReader io.ReadCloser, sessionReplay *SessionReplay) {
 	var (
@@ -104,6 +267,8 @@ func (p *Pipe) HandleResponse(respType message.ResponseMessage_ResponseType, ses
 			}
 
 			p.feedbackInput(buf[:validLen])
+			p.echo.output(buf[:validLen])
+			p.decidePending(buf[:validLen], false)
 
 			if sessionReplay != nil {
 				sessionReplay.record(buf[:validLen])
@@ -182,7 +347,7 @@ func (p *Pipe) feedbackInput(feedback []byte) {
 		case term.HasUpArrowSuffix(input) || term.HasDownArrowSuffix(input):
 			escapedFeedback = term.EscapeHistoryCommand(feedback)
 		default:
-			log.Errorf("Unknown input: %v, will return empty feedback.", input)
+			log.Errorf("Unknown input of %d bytes, will return empty feedback.", len(input))
 		}
 		p.responseBuffer <- escapedFeedback
 	default:
@@ -190,52 +355,104 @@ func (p *Pipe) feedbackInput(feedback []byte) {
 }
 
 func (p *Pipe) handleInput(input []byte, buf *bytes.Buffer, g *global.Global) error {
-	switch {
-	case term.IsCR(input):
-		p.saveCommand(buf.Bytes(), g)
-		buf.Reset()
-	case term.IsTab(input):
+	if term.IsTab(input) && !p.lines.Pasting() {
 		buf.Write(p.askForFeedback(input))
-	default:
-		if _, err := buf.Write(input); err != nil {
+		return nil
+	}
+
+	// A message with more than Enter is pasted, each line in it is executed by the shell
+	pasted := !term.IsCR(input)
+	if p.echo.disabled() {
+		// The programs reading passwords take the newlines in bracketed paste as Enter
+		input = term.StripPasteMarkers(input)
+	}
+	for data, follows := input, false; ; follows = true {
+		keys, rest, enter := p.lines.Split(data)
+		if _, err := buf.Write(keys); err != nil {
 			log.Errorf("buf.Write() failed, error: %s, session: %+v.", err, p.session)
 			return err
 		}
 
-		log.Infof("buf.Write() succeed, inMsg.Content: %v, session: %+v.", input, p.session)
-		switch {
-		case term.HasUpArrowSuffix(buf.Bytes()):
-			feedback := p.askForFeedback(buf.Bytes())
-			buf.Truncate(len(buf.Bytes()) - len(term.UpArrow))
-			buf.Write(feedback)
-		case term.HasDownArrowSuffix(buf.Bytes()):
-			feedback := p.askForFeedback(buf.Bytes())
-			buf.Truncate(len(buf.Bytes()) - len(term.DownArrow))
-			buf.Write(feedback)
+		if !enter {
+			break
 		}
+
+		p.enterLine(buf.Bytes(), pasted, follows, g)
+		buf.Reset()
+		data = rest
+	}
+
+	// The input is not logged, since it may be a secret typed with echo disabled
+	log.Infof("buf.Write() succeed, %d bytes, session: %+v.", len(input), p.session)
+	switch {
+	case p.lines.Pasting():
+	case term.HasUpArrowSuffix(buf.Bytes()):
+		feedback := p.askForFeedback(buf.Bytes())
+		buf.Truncate(len(buf.Bytes()) - len(term.UpArrow))
+		buf.Write(feedback)
+	case term.HasDownArrowSuffix(buf.Bytes()):
+		feedback := p.askForFeedback(buf.Bytes())
+		buf.Truncate(len(buf.Bytes()) - len(term.DownArrow))
+		buf.Write(feedback)
 	}
 
 	return nil
 }
 
-func (p *Pipe) saveCommand(input []byte, g *global.Global) {
-	commandContent := string(term.EscapeInput(input))
+// saveCommand rebuild the lines from the keystrokes, and record the commands completed by them,
+// the history and the kill ring of the session are kept in p.input
+func (p *Pipe) saveCommand(input []byte, pasted bool, g *global.Global) {
+	p.input.Edit(input)
+	pasted = pasted || p.input.Pasted()
+	for _, command := range p.commands.Add(p.input.Accept(), pasted) {
+		// Follow the editing mode of readline switched by the user
+		switch strings.Join(strings.Fields(command.Content), " ") {
+		case "set -o vi":
+			p.input.SetMode(term.ViMode)
+		case "set -o emacs":
+			p.input.SetMode(term.EmacsMode)
+		}
+
+		if strings.TrimSpace(command.Content) != "" {
+			recordCommand(&models.Command{
+				SessionID: p.session.SessionID,
+				User:      p.session.User,
+				Content:   command.Content,
+				Pasted:    command.Pasted,
+			}, p.session, g)
+		}
+	}
+}
+
+// saveSecret note that a secret is entered in the session without recording it
+func (p *Pipe) saveSecret(g *global.Global) {
+	log.Infof("A secret is entered with echo disabled, session: %+v.", p.session)
+	saveSecretEntered(p.session, g)
+}
+
+// SaveCommand record the command of the session, and alert entry owners if it is risky
+func (p *Pipe) SaveCommand(commandContent string, g *global.Global) {
 	if commandContent != "" {
-		command := models.Command{
+		recordCommand(&models.Command{
 			SessionID: p.session.SessionID,
 			User:      p.session.User,
 			Content:   commandContent,
-		}
-		g.DB.Create(&command)
-		if command.IsRisky() {
-			log.Warnf("Dangerous command! Will alert entry owners... Command.Content: %v, session: %+v.", command.Content, p.session)
-			go func() {
-				if err1 := command.Alert(*p.session, g); err1 != nil {
-					log.Errorf("command.Alert() failed, error: %v.", err1)
-				}
-			}()
-		} else {
-			log.Infof("command.Content: %v, session: %+v.", command.Content, p.session)
-		}
+		}, p.session, g)
+	}
+}
+
+func recordCommand(command *models.Command, s *models.Session, g *global.Global) {
+	createCommand(command, g)
+	// Every command in the containers of entry itself is alerted, since they hold the secrets of entry
+	if command.IsRisky() || s.IsEntryApp() {
+		log.Warnf("Dangerous command! Will alert entry owners... Command.Content: %v, session: %+v.", command.Content, s)
+		alerted := *command
+		go func() {
+			if err1 := alerted.Alert(*s, g); err1 != nil {
+				log.Errorf("command.Alert() failed, error: %v.", err1)
+			}
+		}()
+	} else {
+		log.Infof("command.Content: %v, session: %+v.", command.Content, s)
 	}
 }
@@ -0,0 +1,112 @@
+package pipe
+
+import (
+	"bytes"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/laincloud/entry/server/global"
+	"github.com/laincloud/entry/server/models"
+)
+
+func TestHandleInputSecret(t *testing.T) {
+	defer func(create func(*models.Command, *global.Global), save func(*models.Session, *global.Global)) {
+		createCommand, saveSecretEntered = create, save
+	}(createCommand, saveSecretEntered)
+
+	var (
+		commands      []string
+		secretEntered bool
+	)
+	createCommand = func(command *models.Command, g *global.Global) {
+		commands = append(commands, command.Content)
+	}
+	saveSecretEntered = func(s *models.Session, g *global.Global) {
+		secretEntered = true
+	}
+
+	// Each step is the input of the user and the output after it, the output keeps still after it if settled
+	type step struct {
+		input   string
+		output  string
+		settled bool
+	}
+	cases := []struct {
+		steps         []step
+		want          []string
+		secretEntered bool
+	}{
+		{
+			[]step{{"sudo su\r", "sudo su\r\n[sudo] password for alice: ", false}, {"hunter2", "", false}, {"\r", "\r\n# ", false}, {"id\r", "", false}},
+			[]string{"sudo su", "id"},
+			true,
+		},
+		{
+			// The password is pasted with Enter
+			[]step{{"mysql -p\r", "mysql -p\r\nEnter password: ", false}, {"hunter2\r", "\r\nmysql> ", false}},
+			[]string{"mysql -p"},
+			true,
+		},
+		{
+			// The prompt typed by the user does not hide the command
+			[]step{{"ls # password:", "ls # password:", false}, {"\r", "\r\n", false}},
+			[]string{"ls # password:"},
+			false,
+		},
+		{
+			// The password is pasted in bracketed paste, whose newline is Enter for the program reading it
+			[]step{{"", "Password: ", false}, {"\x1b[200~hunter2\n\x1b[201~", "\r\n$ ", false}, {"ls\r", "ls\r\n", false}},
+			[]string{"ls"},
+			true,
+		},
+		{
+			// The prompt of the shell looks like a password prompt, but the commands pasted at it are echoed
+			[]step{{"", "pin> ", false}, {"ls", "ls", false}, {"\r", "\r\na b\r\npin> ", false}, {"id\r", "id\r\nuid=0(root)\r\npin> ", false}},
+			[]string{"ls", "id"},
+			false,
+		},
+		{
+			// The password is pasted with the command asking for it
+			[]step{
+				{"sudo su\rhunter2\rid\r", "sudo su\r\n", true}, {"", "[sudo] password for alice: ", true},
+				{"", "\r\n# id\r\nuid=0(root)\r\n# ", true},
+			},
+			[]string{"sudo su", "id"},
+			true,
+		},
+		{
+			// The lines pasted after a long running command are echoed when the shell reads them
+			[]step{{"sleep 1\rls\r", "sleep 1\r\n", true}, {"", "$ ls\r\na b\r\n$ ", true}},
+			[]string{"sleep 1", "ls"},
+			false,
+		},
+		{
+			// The lines not decided when the session ends are taken as commands
+			[]step{{"cat\rhello\r", "cat\r\n", false}},
+			[]string{"cat", "hello"},
+			false,
+		},
+	}
+
+	for _, c := range cases {
+		commands, secretEntered = nil, false
+		p := NewPipe(nil, nil, &models.Session{SessionID: 1, AppName: "hello"}, nil, &sync.WaitGroup{}, &sync.Mutex{})
+		var buf bytes.Buffer
+		for _, step := range c.steps {
+			if err := p.handleInput([]byte(step.input), &buf, &global.Global{}); err != nil {
+				t.Errorf("p.handleInput(%q) failed, error: %s.", step.input, err)
+			}
+			p.echo.input([]byte(step.input))
+			p.echo.output([]byte(step.output))
+			p.decidePending([]byte(step.output), false)
+			if step.settled {
+				p.decidePending(nil, true)
+			}
+		}
+		p.flushPending()
+		if !reflect.DeepEqual(commands, c.want) || secretEntered != c.secretEntered {
+			t.Errorf("p.handleInput() with %+v, commands == %q, secretEntered == %v, want: %q, %v.", c.steps, commands, secretEntered, c.want, c.secretEntered)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package pipe
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+
+	"github.com/laincloud/entry/server/global"
+	"github.com/laincloud/entry/server/models"
+	"github.com/laincloud/entry/server/term"
+)
+
+const (
+	defaultScreenWidth  = 80
+	defaultScreenHeight = 24
+)
+
+// CommandCapturer capture the commands of the shell from its input and output instead of the keystrokes
+type CommandCapturer interface {
+	// Input observe the input of the shell
+	Input(data []byte)
+	// Output observe the output of the shell and return the output which should be sent to the user
+	Output(data []byte) []byte
+	// Resize observe the size of the terminal
+	Resize(width, height int)
+}
+
+// ScreenTracker rebuild the commands from the screen of the session, which is fed by the output of the shell,
+// the command is read from the line echoed by the shell after the user presses Enter, so the editing of readline
+// such as history search and vi mode is all taken into account
+type ScreenTracker struct {
+	lock      sync.Mutex
+	screen    *term.Screen
+	prompt    string
+	prompting bool
+	entered   int
+	onCommand func(content string)
+}
+
+// NewScreenTracker return an initialized *ScreenTracker which records the commands of the session
+func NewScreenTracker(session *models.Session, g *global.Global) *ScreenTracker {
+	return newScreenTracker(func(content string) {
+		recordCommand(&models.Command{
+			SessionID: session.SessionID,
+			User:      session.User,
+			Content:   content,
+		}, session, g)
+	})
+}
+
+func newScreenTracker(onCommand func(content string)) *ScreenTracker {
+	return &ScreenTracker{
+		screen:    term.NewScreen(defaultScreenWidth, defaultScreenHeight),
+		prompting: true,
+		onCommand: onCommand,
+	}
+}
+
+// Input implement CommandCapturer, the text before the cursor is taken as the prompt when the user starts typing
+func (t *ScreenTracker) Input(data []byte) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if t.screen.AlternateScreen() {
+		// The keys typed in full screen programs such as vim are not commands
+		return
+	}
+
+	if t.prompting {
+		_, t.prompt = t.screen.CursorLine()
+		t.prompting = false
+	}
+	t.entered += bytes.Count(data, []byte{'\r'})
+}
+
+// Output implement CommandCapturer, the line is read as the command before the shell moves to the next line after Enter
+func (t *ScreenTracker) Output(data []byte) []byte {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	output := data
+	for t.entered > 0 {
+		i := bytes.IndexByte(data, '\n')
+		if i < 0 {
+			break
+		}
+
+		t.screen.Write(data[:i])
+		t.readCommand()
+		t.screen.Write(data[i : i+1])
+		data = data[i+1:]
+	}
+	t.screen.Write(data)
+	return output
+}
+
+// Resize implement CommandCapturer
+func (t *ScreenTracker) Resize(width, height int) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.screen.Resize(width, height)
+}
+
+func (t *ScreenTracker) readCommand() {
+	t.entered--
+	t.prompting = true
+	if t.screen.AlternateScreen() {
+		return
+	}
+
+	line, _ := t.screen.CursorLine()
+	var content string
+	switch {
+	case strings.HasPrefix(line, t.prompt):
+		content = line[len(t.prompt):]
+	case strings.HasPrefix(t.prompt, line):
+		// Nothing is typed, the trailing spaces of the prompt are trimmed
+	default:
+		content = line
+	}
+	if content = strings.TrimSpace(content); content != "" {
+		t.onCommand(content)
+	}
+}
@@ -0,0 +1,82 @@
+package pipe
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/laincloud/entry/server/term"
+)
+
+func TestScreenTracker(t *testing.T) {
+	// Each step is either the input of the user or the output of the shell
+	type step struct {
+		input  string
+		output string
+	}
+	cases := []struct {
+		steps []step
+		want  []string
+	}{
+		{
+			[]step{
+				{output: "$ "}, {input: "l"}, {output: "l"}, {input: "s\r"}, {output: "s\r\na b\r\n$ "},
+				{input: "\r"}, {output: "\r\n$ "},
+			},
+			[]string{"ls"},
+		},
+		{
+			// History search with Ctrl-R
+			[]step{
+				{output: "root@web:/# "}, {input: "\x12"}, {output: "\r(reverse-i-search)`': "},
+				{input: "ta"}, {output: "\b\b\bt': tail -f log\033[5D"}, {input: "\r"},
+				{output: "\r\033[Kroot@web:/# tail -f log\r\n"},
+			},
+			[]string{"tail -f log"},
+		},
+		{
+			// The line wraps, and the password is not echoed
+			[]step{
+				{output: "$ "}, {input: "echo 0123456789 0123456789 0123456789"},
+				{output: "echo 0123456789 0123456789 0123456789"}, {input: "\r"},
+				{output: "\r\n0123456789 0123456789 0123456789\r\n$ "}, {input: "sudo su\r"}, {output: "sudo su\r\nPassword: "},
+				{input: "secret\r"}, {output: "\r\n# "},
+			},
+			[]string{"echo 0123456789 0123456789 0123456789", "sudo su"},
+		},
+		{
+			// The keys typed in vim are not commands
+			[]step{
+				{output: "$ "}, {input: "vim\r"}, {output: "vim\r\n\033[?1049h\033[2J~"}, {input: ":wq\r"},
+				{output: "\r\n\033[?1049l$ "},
+			},
+			[]string{"vim"},
+		},
+	}
+
+	for _, c := range cases {
+		var commands []string
+		tracker := newScreenTracker(func(content string) {
+			commands = append(commands, content)
+		})
+		tracker.Resize(40, 5)
+		for _, s := range c.steps {
+			if s.input != "" {
+				tracker.Input([]byte(s.input))
+			}
+			if output := tracker.Output([]byte(s.output)); string(output) != s.output {
+				t.Errorf("tracker.Output(%q) == %q, want: %q.", s.output, output, s.output)
+			}
+		}
+		if !reflect.DeepEqual(commands, c.want) {
+			t.Errorf("commands of %+v == %q, want: %q.", c.steps, commands, c.want)
+		}
+	}
+}
+
+func TestScreenTrackerResizeLimit(t *testing.T) {
+	tracker := newScreenTracker(func(content string) {})
+	tracker.Resize(65535, 65535)
+	if width, height := tracker.screen.Size(); width != term.MaxWidth || height != term.MaxHeight {
+		t.Errorf("tracker.Resize(65535, 65535), tracker.screen.Size() == %d, %d, want: %d, %d.", width, height, term.MaxWidth, term.MaxHeight)
+	}
+}
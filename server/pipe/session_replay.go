@@ -1,18 +1,43 @@
 package pipe
 
 import (
+	"bufio"
+	"bytes"
+	"errors"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"os"
+	"sync"
 	"time"
 
+	"github.com/mijia/sweb/log"
+
 	"github.com/laincloud/entry/server/models"
+	"github.com/laincloud/entry/server/term"
+)
+
+const (
+	watcherBufferSize = 1024
+)
+
+var (
+	errWatcherTooSlow = errors.New("watcher is too slow to catch up with the session")
+
+	liveReplays = struct {
+		sync.RWMutex
+		m map[int64]*SessionReplay
+	}{m: make(map[int64]*SessionReplay)}
 )
 
 // SessionReplay is for session replay
 type SessionReplay struct {
+	lock           sync.Mutex
+	sessionID      int64
 	timingFile     *os.File
 	typescriptFile *os.File
 	now            time.Time
+	watchers       map[*Watcher]struct{}
 }
 
 // NewSessionReplay return an initialized *SessionReplay
@@ -29,15 +54,42 @@ func NewSessionReplay(s models.Session) (*SessionReplay, error) {
 		return nil, err
 	}
 
-	return &SessionReplay{
+	sessionReplay := &SessionReplay{
+		sessionID:      s.SessionID,
 		timingFile:     timingFile,
 		typescriptFile: typescriptFile,
 		now:            time.Now(),
-	}, nil
+		watchers:       make(map[*Watcher]struct{}),
+	}
+	liveReplays.Lock()
+	liveReplays.m[s.SessionID] = sessionReplay
+	liveReplays.Unlock()
+	return sessionReplay, nil
+}
+
+// GetLiveSessionReplay return the *SessionReplay of the active session
+func GetLiveSessionReplay(sessionID int64) (*SessionReplay, bool) {
+	liveReplays.RLock()
+	defer liveReplays.RUnlock()
+	s, ok := liveReplays.m[sessionID]
+	return s, ok
 }
 
 // Close close the underlying files
 func (s *SessionReplay) Close() error {
+	liveReplays.Lock()
+	if liveReplays.m[s.sessionID] == s {
+		delete(liveReplays.m, s.sessionID)
+	}
+	liveReplays.Unlock()
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	for w := range s.watchers {
+		w.close(io.EOF)
+	}
+	s.watchers = nil
+
 	fmt.Fprintf(s.typescriptFile, "Script done on %s\n", time.Now())
 	err1 := s.typescriptFile.Close()
 	err2 := s.timingFile.Close()
@@ -51,11 +103,167 @@ func (s *SessionReplay) Close() error {
 	}
 }
 
+// Watch return a *Watcher which receives the recording so far and all the following output of the session
+func (s *SessionReplay) Watch() (*Watcher, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.watchers == nil {
+		return nil, io.EOF
+	}
+
+	recording, err := ioutil.ReadFile(s.typescriptFile.Name())
+	if err != nil {
+		return nil, err
+	}
+
+	// Skip the "Script started on ..." line, just like scriptreplay
+	if i := bytes.IndexByte(recording, '\n'); i >= 0 {
+		recording = recording[i+1:]
+	}
+
+	w := newWatcher(recording)
+	w.sessionReplay = s
+	s.watchers[w] = struct{}{}
+	return w, nil
+}
+
+// Write implement io.Writer, data is recorded as the output of the session
+func (s *SessionReplay) Write(data []byte) (int, error) {
+	s.record(data)
+	return len(data), nil
+}
+
 // record write down response and delay in respective files for future replay
 func (s *SessionReplay) record(data []byte) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
 	now := time.Now()
 	delay := now.Sub(s.now)
 	s.now = now
 	s.typescriptFile.Write(data)
 	fmt.Fprintf(s.timingFile, "%f %d\n", float64(delay)/1e9, len(data))
+
+	for w := range s.watchers {
+		if !w.send(data) {
+			log.Warnf("Watcher of session: %d is too slow, will drop it.", s.sessionID)
+			w.close(errWatcherTooSlow)
+			delete(s.watchers, w)
+		}
+	}
+}
+
+func (s *SessionReplay) removeWatcher(w *Watcher) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.watchers != nil {
+		delete(s.watchers, w)
+	}
+}
+
+// Watcher is a read-only observer of an active session
+type Watcher struct {
+	data          chan []byte
+	done          chan struct{}
+	err           error
+	once          sync.Once
+	pending       []byte
+	sessionReplay *SessionReplay
+}
+
+func newWatcher(recording []byte) *Watcher {
+	return &Watcher{
+		data:    make(chan []byte, watcherBufferSize),
+		done:    make(chan struct{}),
+		pending: recording,
+	}
+}
+
+// Read implement io.Reader, it blocks until there is new output of the session
+func (w *Watcher) Read(p []byte) (int, error) {
+	for len(w.pending) == 0 {
+		select {
+		case data := <-w.data:
+			w.pending = data
+		case <-w.done:
+			select {
+			case data := <-w.data:
+				w.pending = data
+			default:
+				return 0, w.err
+			}
+		}
+	}
+
+	n := copy(p, w.pending)
+	w.pending = w.pending[n:]
+	return n, nil
+}
+
+// Close stop watching the session
+func (w *Watcher) Close() error {
+	w.sessionReplay.removeWatcher(w)
+	w.close(io.EOF)
+	return nil
+}
+
+func (w *Watcher) send(data []byte) bool {
+	select {
+	case w.data <- append([]byte(nil), data...):
+		return true
+	default:
+		return false
+	}
+}
+
+func (w *Watcher) close(err error) {
+	w.once.Do(func() {
+		w.err = err
+		close(w.done)
+	})
+}
+
+// ReplayScreen replay the recording of the session until the offset in the emulated screen of the size,
+// the whole recording is replayed if offset is negative, it return the screen and the offset reached
+func ReplayScreen(s models.Session, offset time.Duration, width, height int) (*term.Screen, time.Duration, error) {
+	timingFile, err := os.Open(s.TimingFile())
+	if err != nil {
+		return nil, 0, err
+	}
+	defer timingFile.Close()
+
+	typescriptFile, err := os.Open(s.TypescriptFile())
+	if err != nil {
+		return nil, 0, err
+	}
+	defer typescriptFile.Close()
+
+	// Skip the "Script started on ..." line, just like scriptreplay
+	typescript := bufio.NewReader(typescriptFile)
+	if _, err = typescript.ReadBytes('\n'); err != nil {
+		return nil, 0, err
+	}
+
+	screen := term.NewScreen(width, height)
+	var elapsed time.Duration
+	scanner := bufio.NewScanner(timingFile)
+	for scanner.Scan() {
+		var (
+			delay float64
+			size  int64
+		)
+		if _, err = fmt.Sscanf(scanner.Text(), "%f %d", &delay, &size); err != nil {
+			return nil, 0, err
+		}
+
+		next := elapsed + time.Duration(delay*float64(time.Second))
+		if offset >= 0 && next > offset {
+			break
+		}
+
+		if _, err = io.CopyN(screen, typescript, size); err != nil {
+			return nil, 0, err
+		}
+		elapsed = next
+	}
+	return screen, elapsed, scanner.Err()
 }
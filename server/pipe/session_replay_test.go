@@ -0,0 +1,36 @@
+package pipe
+
+import (
+	"io"
+	"io/ioutil"
+	"testing"
+)
+
+func TestWatcher(t *testing.T) {
+	w := newWatcher([]byte("recorded "))
+	w.sessionReplay = &SessionReplay{}
+	if !w.send([]byte("live ")) || !w.send([]byte("output")) {
+		t.Fatal("w.send() failed.")
+	}
+	w.close(io.EOF)
+
+	got, err := ioutil.ReadAll(w)
+	if err != nil {
+		t.Fatalf("ioutil.ReadAll() failed, error: %s.", err)
+	}
+	if want := "recorded live output"; string(got) != want {
+		t.Errorf("ioutil.ReadAll() == %q, want: %q.", got, want)
+	}
+}
+
+func TestWatcherTooSlow(t *testing.T) {
+	w := newWatcher(nil)
+	for i := 0; i < watcherBufferSize; i++ {
+		if !w.send([]byte{'a'}) {
+			t.Fatalf("w.send() failed at %d.", i)
+		}
+	}
+	if w.send([]byte{'a'}) {
+		t.Error("w.send() should fail when the buffer is full.")
+	}
+}
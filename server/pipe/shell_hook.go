@@ -0,0 +1,226 @@
+package pipe
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/mijia/sweb/log"
+
+	"github.com/laincloud/entry/server/global"
+	"github.com/laincloud/entry/server/models"
+)
+
+const (
+	hookTokenSize = 8
+	// maxHookReportSize is the longest report kept while waiting for its end, longer ones are sent as output
+	maxHookReportSize = 64 * 1024
+	hookReportStart   = "start"
+	hookReportEnd     = "end"
+	hookReportPrefix  = "\033]5379;"
+	hookReportSuffix  = '\a'
+	// shellHookScript is the rc file of bash, it reports the command before it runs and its exit code before the next
+	// prompt, the reports are private OSC escape sequences, and the fields are percent encoded
+	shellHookScript = `[ -f /etc/bash.bashrc ] && . /etc/bash.bashrc
+[ -f ~/.bashrc ] && . ~/.bashrc
+__entry_escape() {
+	local v=${1//%%/%%25}
+	v=${v//;/%%3B}
+	v=${v//$'\a'/%%07}
+	v=${v//$'\e'/%%1B}
+	v=${v//$'\n'/%%0A}
+	__entry_escaped=${v//$'\r'/%%0D}
+}
+__entry_history() {
+	local line
+	line=$(HISTTIMEFORMAT= builtin history 1)
+	[[ $line =~ ^[[:space:]]*([0-9]+)\*?[[:space:]]+(.*)$ ]]
+}
+__entry_preexec() {
+	[ -n "$__entry_prompted" ] && [ -z "$COMP_LINE" ] && [ "$BASH_COMMAND" != __entry_precmd ] || return
+	__entry_prompted=
+	local command=$BASH_COMMAND
+	if __entry_history && [ "${BASH_REMATCH[1]}" != "$__entry_histno" ]; then
+		__entry_histno=${BASH_REMATCH[1]}
+		command=${BASH_REMATCH[2]}
+	fi
+	__entry_escape "$PWD"
+	local dir=$__entry_escaped
+	__entry_escape "$command"
+	builtin printf '\033]5379;%[1]s;%[2]s;%%s;%%s\a' "$dir" "$__entry_escaped"
+	__entry_started=1
+}
+__entry_precmd() {
+	local status=$?
+	[ -n "$__entry_started" ] && builtin printf '\033]5379;%[1]s;%[3]s;%%s\a' "$status"
+	__entry_started=
+	__entry_prompted=1
+}
+__entry_history && __entry_histno=${BASH_REMATCH[1]}
+PROMPT_COMMAND="__entry_precmd${PROMPT_COMMAND:+; $PROMPT_COMMAND}"
+trap __entry_preexec DEBUG
+`
+)
+
+// ShellHook capture the commands executed by bash through the hook injected into its rc file,
+// so that the commands run by history search, aliases or pasted blocks are recorded exactly as executed,
+// the reports of the hook are removed from the output sent to the user, and kept in the recording,
+// since the user can disable the hook, the commands rebuilt from the keystrokes are recorded as well
+type ShellHook struct {
+	session *models.Session
+	g       *global.Global
+	token   string
+	marker  []byte
+	pending []byte
+	running *models.Command
+}
+
+// hookReport is a report of the hook, either a command starting in the work directory or the exit code of it
+type hookReport struct {
+	kind     string
+	workDir  string
+	content  string
+	exitCode int
+}
+
+// NewShellHook return an initialized *ShellHook, the token in the reports keeps the output from being mistaken for them
+func NewShellHook(session *models.Session, g *global.Global) (*ShellHook, error) {
+	token := make([]byte, hookTokenSize)
+	if _, err := rand.Read(token); err != nil {
+		return nil, err
+	}
+
+	return newShellHook(hex.EncodeToString(token), session, g), nil
+}
+
+func newShellHook(token string, session *models.Session, g *global.Global) *ShellHook {
+	return &ShellHook{
+		session: session,
+		g:       g,
+		token:   token,
+		marker:  []byte(fmt.Sprintf("%s%s;", hookReportPrefix, token)),
+	}
+}
+
+// RCFile return the content of the rc file which bash should be run with
+func (h *ShellHook) RCFile() string {
+	return fmt.Sprintf(shellHookScript, h.token, hookReportStart, hookReportEnd)
+}
+
+// Input implement CommandCapturer, the input is not needed since the hook reports the commands
+func (h *ShellHook) Input(data []byte) {}
+
+// Resize implement CommandCapturer
+func (h *ShellHook) Resize(width, height int) {}
+
+// Output implement CommandCapturer, it record the commands reported in the output and return the output without
+// the reports, the incomplete report at the end is kept until the following output arrives
+func (h *ShellHook) Output(data []byte) []byte {
+	output, reports := h.parse(data)
+	now := time.Now()
+	for _, r := range reports {
+		switch r.kind {
+		case hookReportStart:
+			h.running = &models.Command{
+				SessionID: h.session.SessionID,
+				User:      h.session.User,
+				Content:   r.content,
+				WorkDir:   r.workDir,
+				ExitCode:  models.UnknownExitCode,
+				Captured:  true,
+				CreatedAt: now,
+			}
+			recordCommand(h.running, h.session, h.g)
+		case hookReportEnd:
+			if h.running != nil {
+				h.running.SaveExit(r.exitCode, now.Sub(h.running.CreatedAt), h.g)
+				h.running = nil
+			}
+		}
+	}
+	return output
+}
+
+func (h *ShellHook) parse(data []byte) ([]byte, []hookReport) {
+	data = append(h.pending, data...)
+	h.pending = nil
+	var (
+		output  []byte
+		reports []hookReport
+	)
+	for len(data) > 0 {
+		i := bytes.Index(data, h.marker)
+		if i < 0 {
+			// Keep the tail which may be the beginning of a report
+			keep := partialSuffix(data, h.marker)
+			output = append(output, data[:len(data)-keep]...)
+			h.pending = append(h.pending, data[len(data)-keep:]...)
+			break
+		}
+
+		output = append(output, data[:i]...)
+		data = data[i:]
+		end := bytes.IndexByte(data, hookReportSuffix)
+		if end < 0 {
+			if len(data) > maxHookReportSize {
+				output = append(output, data...)
+			} else {
+				h.pending = append(h.pending, data...)
+			}
+			break
+		}
+
+		if r, err := parseHookReport(data[len(h.marker):end]); err != nil {
+			log.Errorf("parseHookReport(%q) failed, error: %s, session: %+v.", data[:end+1], err, h.session)
+		} else {
+			reports = append(reports, r)
+		}
+		data = data[end+1:]
+	}
+	return output, reports
+}
+
+// partialSuffix return the length of the longest suffix of data which is a prefix of marker
+func partialSuffix(data, marker []byte) int {
+	n := len(marker) - 1
+	if n > len(data) {
+		n = len(data)
+	}
+	for ; n > 0; n-- {
+		if bytes.HasSuffix(data, marker[:n]) {
+			return n
+		}
+	}
+	return 0
+}
+
+func parseHookReport(report []byte) (hookReport, error) {
+	fields := bytes.Split(report, []byte(";"))
+	switch {
+	case string(fields[0]) == hookReportStart && len(fields) == 3:
+		workDir, err := url.PathUnescape(string(fields[1]))
+		if err != nil {
+			return hookReport{}, err
+		}
+
+		content, err := url.PathUnescape(string(fields[2]))
+		if err != nil {
+			return hookReport{}, err
+		}
+
+		return hookReport{kind: hookReportStart, workDir: workDir, content: content}, nil
+	case string(fields[0]) == hookReportEnd && len(fields) == 2:
+		exitCode, err := strconv.Atoi(string(fields[1]))
+		if err != nil {
+			return hookReport{}, err
+		}
+
+		return hookReport{kind: hookReportEnd, exitCode: exitCode}, nil
+	default:
+		return hookReport{}, fmt.Errorf("unknown report: %s", report)
+	}
+}
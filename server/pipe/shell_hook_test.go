@@ -0,0 +1,56 @@
+package pipe
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestShellHookParse(t *testing.T) {
+	cases := []struct {
+		chunks      []string
+		wantOutput  string
+		wantReports []hookReport
+	}{
+		{
+			[]string{"$ \033]5379;abc;start;/tmp;ls%3B pwd\a/tmp\r\n\033]5379;abc;end;0\a$ "},
+			"$ /tmp\r\n$ ",
+			[]hookReport{
+				{kind: hookReportStart, workDir: "/tmp", content: "ls; pwd"},
+				{kind: hookReportEnd, exitCode: 0},
+			},
+		},
+		{
+			[]string{"a\033]53", "79;abc;start;/ro", "ot;for i in 1%0Ado echo%25 $i%0Adone\a", "b\033", "]5379;abc;end;127\ac"},
+			"abc",
+			[]hookReport{
+				{kind: hookReportStart, workDir: "/root", content: "for i in 1\ndo echo% $i\ndone"},
+				{kind: hookReportEnd, exitCode: 127},
+			},
+		},
+		{
+			[]string{"\033]5379;xyz;end;0\a\033[0m\033]0;title\a"},
+			"\033]5379;xyz;end;0\a\033[0m\033]0;title\a",
+			nil,
+		},
+		{
+			[]string{"\033]5379;abc;bad\a\033]5379;abc;end;x\aok"},
+			"ok",
+			nil,
+		},
+	}
+	for _, c := range cases {
+		h := newShellHook("abc", nil, nil)
+		var (
+			output  string
+			reports []hookReport
+		)
+		for _, chunk := range c.chunks {
+			o, r := h.parse([]byte(chunk))
+			output += string(o)
+			reports = append(reports, r...)
+		}
+		if output != c.wantOutput || !reflect.DeepEqual(reports, c.wantReports) {
+			t.Errorf("h.parse(%q) == %q, %+v, want: %q, %+v.", c.chunks, output, reports, c.wantOutput, c.wantReports)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package pipe
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/laincloud/entry/server/global"
+	"github.com/laincloud/entry/server/models"
+)
+
+// allowedSignals are the signals the client can send, mapped to the names kill accepts
+var allowedSignals = map[string]string{
+	"SIGINT":  "INT",
+	"SIGTERM": "TERM",
+	"SIGQUIT": "QUIT",
+}
+
+// HandleSignal deliver the signal requested by the client to the foreground processes of the session,
+// or the main process of the container for attach sessions, every signal is audited
+func (p *Pipe) HandleSignal(name string, g *global.Global) error {
+	name = signalName(name)
+	var err error
+	if signal, ok := allowedSignals[name]; ok {
+		if p.session.Type == models.SessionTypeAttach {
+			err = p.session.SignalContainer(signal, g)
+		} else {
+			err = p.session.SignalForeground(p.execID, signal, g)
+		}
+	} else {
+		err = fmt.Errorf("signal: %s is not allowed", name)
+	}
+	p.session.RecordEvent(models.SessionEventTypeSignal, name, err, g)
+	return err
+}
+
+// signalName normalize INT, int and SIGINT to SIGINT
+func signalName(name string) string {
+	name = strings.ToUpper(strings.TrimSpace(name))
+	if !strings.HasPrefix(name, "SIG") {
+		name = "SIG" + name
+	}
+	return name
+}
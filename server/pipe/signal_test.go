@@ -0,0 +1,31 @@
+package pipe
+
+import (
+	"testing"
+)
+
+func TestSignalName(t *testing.T) {
+	cases := []struct {
+		in   string
+		want string
+	}{
+		{
+			in:   "SIGINT",
+			want: "SIGINT",
+		},
+		{
+			in:   "int",
+			want: "SIGINT",
+		},
+		{
+			in:   " Term\n",
+			want: "SIGTERM",
+		},
+	}
+
+	for _, c := range cases {
+		if got := signalName(c.in); got != c.want {
+			t.Errorf("signalName(%q) == %s, want: %s.", c.in, got, c.want)
+		}
+	}
+}
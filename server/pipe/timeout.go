@@ -0,0 +1,119 @@
+package pipe
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/mijia/sweb/log"
+
+	"github.com/laincloud/entry/server/global"
+	"github.com/laincloud/entry/server/models"
+)
+
+const (
+	timeoutCheckInterval = time.Second
+)
+
+// Timeouts denotes when the exec should be stopped, zero values mean no limit
+type Timeouts struct {
+	Idle        time.Duration
+	MaxDuration time.Duration
+	Warning     time.Duration
+}
+
+// deadline return the earlier one of the idle deadline and the max duration deadline, and the reason
+func (t Timeouts) deadline(startedAt, lastInput time.Time) (time.Time, string) {
+	var (
+		deadline time.Time
+		reason   string
+	)
+	if t.MaxDuration > 0 {
+		deadline, reason = startedAt.Add(t.MaxDuration), models.ExitReasonMaxDuration
+	}
+	if t.Idle > 0 {
+		if idleDeadline := lastInput.Add(t.Idle); deadline.IsZero() || idleDeadline.Before(deadline) {
+			deadline, reason = idleDeadline, models.ExitReasonIdleTimeout
+		}
+	}
+	return deadline, reason
+}
+
+// WatchTimeouts warn the user before the exec reaches the timeouts, and stop it after that, until the exec is closed
+func (e *Exec) WatchTimeouts(t Timeouts, g *global.Global) {
+	if t.Idle <= 0 && t.MaxDuration <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(timeoutCheckInterval)
+	defer ticker.Stop()
+	var warned time.Time
+	for {
+		var now time.Time
+		select {
+		case <-e.done:
+			return
+		case now = <-ticker.C:
+		}
+
+		deadline, reason := t.deadline(e.startedAt, time.Unix(0, atomic.LoadInt64(&e.lastInput)))
+		switch {
+		case !now.Before(deadline):
+			e.timeout(reason, g)
+			return
+		case now.Add(t.Warning).After(deadline) && !deadline.Equal(warned):
+			warned = deadline
+			e.notify(fmt.Sprintf("Your session will be closed in %s because of %s.", deadline.Sub(now).Round(time.Second), timeoutDescription(reason)))
+		}
+	}
+}
+
+// StopReason return why entry stopped the exec, it is empty if the exec is not stopped by timeouts
+func (e *Exec) StopReason() string {
+	e.stopLock.Lock()
+	defer e.stopLock.Unlock()
+	return e.stopReason
+}
+
+func (e *Exec) timeout(reason string, g *global.Global) {
+	e.stopLock.Lock()
+	e.stopReason = reason
+	e.stopLock.Unlock()
+
+	log.Warnf("Exec: %s reached %s, will be stopped, session: %+v.", e.ID, reason, e.Session)
+	e.notify(fmt.Sprintf("Your session is closed because of %s.", timeoutDescription(reason)))
+	err := e.Session.StopExec(e.ID, g.Config.Session.CleanupGracePeriod(), g)
+	if err != nil {
+		log.Errorf("e.Session.StopExec() failed, error: %s, session: %+v.", err, e.Session)
+	}
+	e.stopLock.Lock()
+	e.cleanup = models.CleanupStatusOf(err)
+	e.stopLock.Unlock()
+	close(e.stopped)
+}
+
+// CleanupStatus wait until entry has stopped the exec, and return the cleanup status,
+// it is empty if the exec is not stopped by timeouts
+func (e *Exec) CleanupStatus() string {
+	if e.StopReason() == "" {
+		return ""
+	}
+
+	<-e.stopped
+	e.stopLock.Lock()
+	defer e.stopLock.Unlock()
+	return e.cleanup
+}
+
+// notify write the message to the terminal of the user
+func (e *Exec) notify(msg string) {
+	e.Stdout.write([]byte(fmt.Sprintf("\r\n\033[33m>>> %s\033[0m\r\n", msg)))
+}
+
+func timeoutDescription(reason string) string {
+	if reason == models.ExitReasonIdleTimeout {
+		return "no input for a long time"
+	}
+
+	return "the maximum session duration"
+}
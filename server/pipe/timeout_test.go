@@ -0,0 +1,48 @@
+package pipe
+
+import (
+	"testing"
+	"time"
+
+	"github.com/laincloud/entry/server/models"
+)
+
+func TestTimeoutsDeadline(t *testing.T) {
+	startedAt := time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)
+	cases := []struct {
+		timeouts   Timeouts
+		lastInput  time.Time
+		wantAt     time.Time
+		wantReason string
+	}{
+		{
+			timeouts:  Timeouts{},
+			lastInput: startedAt,
+		},
+		{
+			timeouts:   Timeouts{Idle: time.Hour},
+			lastInput:  startedAt.Add(time.Minute),
+			wantAt:     startedAt.Add(time.Hour + time.Minute),
+			wantReason: models.ExitReasonIdleTimeout,
+		},
+		{
+			timeouts:   Timeouts{Idle: time.Hour, MaxDuration: 2 * time.Hour},
+			lastInput:  startedAt.Add(90 * time.Minute),
+			wantAt:     startedAt.Add(2 * time.Hour),
+			wantReason: models.ExitReasonMaxDuration,
+		},
+		{
+			timeouts:   Timeouts{Idle: time.Hour, MaxDuration: 2 * time.Hour},
+			lastInput:  startedAt.Add(30 * time.Minute),
+			wantAt:     startedAt.Add(90 * time.Minute),
+			wantReason: models.ExitReasonIdleTimeout,
+		},
+	}
+
+	for _, c := range cases {
+		gotAt, gotReason := c.timeouts.deadline(startedAt, c.lastInput)
+		if !gotAt.Equal(c.wantAt) || gotReason != c.wantReason {
+			t.Errorf("%+v.deadline() == %s, %s, want: %s, %s.", c.timeouts, gotAt, gotReason, c.wantAt, c.wantReason)
+		}
+	}
+}
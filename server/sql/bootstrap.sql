@@ -1,5 +1,6 @@
 CREATE TABLE `sessions` (
 `session_id` bigint(20) NOT NULL AUTO_INCREMENT,
+`parent_id` bigint(20) DEFAULT NULL,
 `user` varchar(255) DEFAULT NULL,
 `source_ip` varchar(255) DEFAULT NULL,
 `app_name` varchar(255) DEFAULT NULL,
@@ -8,13 +9,25 @@ CREATE TABLE `sessions` (
 `container_id` varchar(255) DEFAULT NULL,
 `node_ip` varchar(255) DEFAULT NULL,
 `status` varchar(255) DEFAULT NULL,
+`terminated_by` varchar(255) DEFAULT NULL,
+`cleanup_status` varchar(255) DEFAULT NULL,
+`type` varchar(255) NOT NULL DEFAULT 'enter',
+`target_port` int(11) DEFAULT NULL,
+`shell` varchar(255) DEFAULT NULL,
+`bytes_in` bigint(20) DEFAULT NULL,
+`bytes_out` bigint(20) DEFAULT NULL,
+`exit_code` int(11) DEFAULT NULL,
+`exit_reason` varchar(255) DEFAULT NULL,
+`container_running` tinyint(1) DEFAULT NULL,
+`secret_entered` tinyint(1) DEFAULT NULL,
 `ended_at` timestamp NULL DEFAULT NULL,
 `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
 `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
 PRIMARY KEY (`session_id`),
 KEY `idx_sessions_user` (`user`(191)),
 KEY `idx_sessions_source_ip` (`source_ip`(191)),
-KEY `idx_sessions_app_name` (`app_name`(191))
+KEY `idx_sessions_app_name` (`app_name`(191)),
+KEY `idx_sessions_parent_id` (`parent_id`)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
 
 CREATE TABLE `commands` (
@@ -22,8 +35,42 @@ CREATE TABLE `commands` (
 `session_id` bigint(20) DEFAULT NULL,
 `user` varchar(255) DEFAULT NULL,
 `content` varchar(1024) DEFAULT NULL,
+`work_dir` varchar(1024) DEFAULT NULL,
+`exit_code` int(11) DEFAULT NULL,
+`duration` bigint(20) DEFAULT NULL,
+`captured` tinyint(1) DEFAULT NULL,
+`pasted` tinyint(1) DEFAULT NULL,
 `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
 PRIMARY KEY (`command_id`),
 KEY `idx_commands_user` (`user`(191)),
 FOREIGN KEY (`session_id`) REFERENCES `sessions`(`session_id`)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE `file_transfers` (
+`file_transfer_id` bigint(20) NOT NULL AUTO_INCREMENT,
+`session_id` bigint(20) DEFAULT NULL,
+`user` varchar(255) DEFAULT NULL,
+`direction` varchar(255) DEFAULT NULL,
+`path` varchar(1024) DEFAULT NULL,
+`size` bigint(20) DEFAULT NULL,
+`checksum` varchar(255) DEFAULT NULL,
+`status` varchar(255) DEFAULT NULL,
+`error` varchar(1024) DEFAULT NULL,
+`created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+PRIMARY KEY (`file_transfer_id`),
+KEY `idx_file_transfers_user` (`user`(191)),
+FOREIGN KEY (`session_id`) REFERENCES `sessions`(`session_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE `session_events` (
+`event_id` bigint(20) NOT NULL AUTO_INCREMENT,
+`session_id` bigint(20) DEFAULT NULL,
+`user` varchar(255) DEFAULT NULL,
+`type` varchar(255) DEFAULT NULL,
+`content` varchar(1024) DEFAULT NULL,
+`error` varchar(1024) DEFAULT NULL,
+`created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+PRIMARY KEY (`event_id`),
+KEY `idx_session_events_user` (`user`(191)),
+FOREIGN KEY (`session_id`) REFERENCES `sessions`(`session_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
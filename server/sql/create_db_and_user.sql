@@ -2,6 +2,8 @@ create database entry;
 
 create user entry@'%' identified by 'password';
 
-grant select, insert, update(status, ended_at, updated_at) on entry.sessions to entry@'%';
-grant select, insert on entry.commands to entry@'%';
+grant select, insert, update(status, terminated_by, cleanup_status, bytes_in, bytes_out, exit_code, exit_reason, container_running, secret_entered, ended_at, updated_at) on entry.sessions to entry@'%';
+grant select, insert, update(exit_code, duration) on entry.commands to entry@'%';
+grant select, insert on entry.file_transfers to entry@'%';
+grant select, insert on entry.session_events to entry@'%';
 flush privileges;
@@ -0,0 +1,58 @@
+-- Upgrade the tables created by the earlier bootstrap.sql, run it as an administrator before deploying the new entry
+
+ALTER TABLE `sessions`
+ADD COLUMN `parent_id` bigint(20) DEFAULT NULL AFTER `session_id`,
+ADD COLUMN `terminated_by` varchar(255) DEFAULT NULL AFTER `status`,
+ADD COLUMN `cleanup_status` varchar(255) DEFAULT NULL AFTER `terminated_by`,
+ADD COLUMN `type` varchar(255) NOT NULL DEFAULT 'enter' AFTER `cleanup_status`,
+ADD COLUMN `target_port` int(11) DEFAULT NULL AFTER `type`,
+ADD COLUMN `shell` varchar(255) DEFAULT NULL AFTER `target_port`,
+ADD COLUMN `bytes_in` bigint(20) DEFAULT NULL AFTER `shell`,
+ADD COLUMN `bytes_out` bigint(20) DEFAULT NULL AFTER `bytes_in`,
+ADD COLUMN `exit_code` int(11) DEFAULT NULL AFTER `bytes_out`,
+ADD COLUMN `exit_reason` varchar(255) DEFAULT NULL AFTER `exit_code`,
+ADD COLUMN `container_running` tinyint(1) DEFAULT NULL AFTER `exit_reason`,
+ADD COLUMN `secret_entered` tinyint(1) DEFAULT NULL AFTER `container_running`,
+ADD KEY `idx_sessions_parent_id` (`parent_id`);
+
+ALTER TABLE `commands`
+ADD COLUMN `work_dir` varchar(1024) DEFAULT NULL AFTER `content`,
+ADD COLUMN `exit_code` int(11) DEFAULT NULL AFTER `work_dir`,
+ADD COLUMN `duration` bigint(20) DEFAULT NULL AFTER `exit_code`,
+ADD COLUMN `captured` tinyint(1) DEFAULT NULL AFTER `duration`,
+ADD COLUMN `pasted` tinyint(1) DEFAULT NULL AFTER `captured`;
+
+CREATE TABLE IF NOT EXISTS `file_transfers` (
+`file_transfer_id` bigint(20) NOT NULL AUTO_INCREMENT,
+`session_id` bigint(20) DEFAULT NULL,
+`user` varchar(255) DEFAULT NULL,
+`direction` varchar(255) DEFAULT NULL,
+`path` varchar(1024) DEFAULT NULL,
+`size` bigint(20) DEFAULT NULL,
+`checksum` varchar(255) DEFAULT NULL,
+`status` varchar(255) DEFAULT NULL,
+`error` varchar(1024) DEFAULT NULL,
+`created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+PRIMARY KEY (`file_transfer_id`),
+KEY `idx_file_transfers_user` (`user`(191)),
+FOREIGN KEY (`session_id`) REFERENCES `sessions`(`session_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS `session_events` (
+`event_id` bigint(20) NOT NULL AUTO_INCREMENT,
+`session_id` bigint(20) DEFAULT NULL,
+`user` varchar(255) DEFAULT NULL,
+`type` varchar(255) DEFAULT NULL,
+`content` varchar(1024) DEFAULT NULL,
+`error` varchar(1024) DEFAULT NULL,
+`created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+PRIMARY KEY (`event_id`),
+KEY `idx_session_events_user` (`user`(191)),
+FOREIGN KEY (`session_id`) REFERENCES `sessions`(`session_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+grant select, insert, update(status, terminated_by, cleanup_status, bytes_in, bytes_out, exit_code, exit_reason, container_running, secret_entered, ended_at, updated_at) on entry.sessions to entry@'%';
+grant select, insert, update(exit_code, duration) on entry.commands to entry@'%';
+grant select, insert on entry.file_transfers to entry@'%';
+grant select, insert on entry.session_events to entry@'%';
+flush privileges;
@@ -1,9 +1,33 @@
 package term
 
-// Input denotes user input from terminal
+import (
+	"strings"
+	"unicode"
+)
+
+// Input denotes user input from terminal, it models the line editing of readline
 type Input struct {
 	buffer []rune
 	cursor int
+
+	mode       EditingMode
+	history    [][]rune
+	historyIdx int    // the index of the line being edited in history, len(history) denotes the new line
+	newLine    []rune // the new line saved while browsing history
+	killRing   [][]rune
+	yankIdx    int
+	yankStart  int
+	undoList   []snapshot
+	undoGroup  bool
+	prevAction action
+	lastAction action
+	quoted     bool // the next key is inserted literally
+	ctrlX      bool // the next key is read with the Ctrl-x prefix
+	pasting    bool // in bracketed paste
+	pasted     bool
+	search     *search
+	lastSearch []rune
+	vi         viState
 }
 
 // NewInput return an initialized *Input
@@ -42,42 +66,204 @@ func (in *Input) DeleteCharacterUnderCursor() {
 // DeleteCharactersAfterCursor delete the character after the cursor
 func (in *Input) DeleteCharactersAfterCursor() {
 	// Ctrl-k, delete characters after the cursor
-	in.buffer = in.buffer[:in.cursor]
+	in.killRange(in.cursor, len(in.buffer))
 }
 
 // DeleteCharactersBeforeCursor delete characters before the cursor
 func (in *Input) DeleteCharactersBeforeCursor() {
-	in.buffer = in.buffer[in.cursor:]
-	in.cursor = 0
+	in.killRange(0, in.cursor)
 }
 
-// DeleteOneWordBeforeCursor delete word before the cursor
+// DeleteOneWordBeforeCursor delete word before the cursor, the words are delimited by whitespaces
 func (in *Input) DeleteOneWordBeforeCursor() {
-	if in.cursor == 0 {
+	i := in.cursor
+	for i > 0 && isBlank(in.buffer[i-1]) {
+		i--
+	}
+	for i > 0 && !isBlank(in.buffer[i-1]) {
+		i--
+	}
+	in.killRange(i, in.cursor)
+}
+
+// DeleteBackOneWord delete back to the beginning of the word, the words consist of letters and digits
+func (in *Input) DeleteBackOneWord() {
+	end := in.cursor
+	in.GoBackOneWord()
+	in.killRange(in.cursor, end)
+}
+
+// DeleteOneWordAfterCursor delete to the end of the word after the cursor, the words consist of letters and digits
+func (in *Input) DeleteOneWordAfterCursor() {
+	start := in.cursor
+	in.GoForwardOneWord()
+	in.killRange(start, in.cursor)
+}
+
+// ClearLine discard the whole line, just like Ctrl-c
+func (in *Input) ClearLine() {
+	in.buffer = in.buffer[:0]
+	in.cursor = 0
+}
+
+// TransposeCharacters swap the character before the cursor with the one under the cursor, and move forward
+func (in *Input) TransposeCharacters() {
+	if in.cursor == 0 || len(in.buffer) < 2 {
 		return
 	}
 
-	hasSpace := false
-	hasNonSpace := false
-	for i := in.cursor - 1; i >= 0; i-- {
-		if in.buffer[i] == ' ' {
-			if !hasNonSpace {
-				continue
-			}
+	if in.cursor == len(in.buffer) {
+		// At the end of the line, the last two characters are swapped
+		in.cursor--
+	}
+	in.buffer[in.cursor-1], in.buffer[in.cursor] = in.buffer[in.cursor], in.buffer[in.cursor-1]
+	in.cursor++
+}
 
-			in.buffer = append(in.buffer[:i+1], in.buffer[in.cursor:]...)
-			in.cursor = i + 1
-			hasSpace = true
-			break
-		} else {
-			hasNonSpace = true
+// UpcaseWord convert the word after the cursor to upper case
+func (in *Input) UpcaseWord() {
+	in.mapWord(func(i int, r rune) rune { return unicode.ToUpper(r) })
+}
+
+// DowncaseWord convert the word after the cursor to lower case
+func (in *Input) DowncaseWord() {
+	in.mapWord(func(i int, r rune) rune { return unicode.ToLower(r) })
+}
+
+// CapitalizeWord capitalize the word after the cursor
+func (in *Input) CapitalizeWord() {
+	first := -1
+	in.mapWord(func(i int, r rune) rune {
+		if first < 0 && isAlphabetOrNumeric(r) {
+			first = i
+			return unicode.ToUpper(r)
 		}
+		return unicode.ToLower(r)
+	})
+}
+
+func (in *Input) mapWord(f func(i int, r rune) rune) {
+	start := in.cursor
+	in.GoForwardOneWord()
+	for i := start; i < in.cursor; i++ {
+		in.buffer[i] = f(i, in.buffer[i])
+	}
+}
+
+// Yank insert the text killed most recently
+func (in *Input) Yank() {
+	if len(in.killRing) == 0 {
+		return
+	}
+
+	in.yankIdx = 0
+	in.yankStart = in.cursor
+	in.insert(in.killRing[len(in.killRing)-1]...)
+	in.lastAction = actionYank
+}
+
+// YankPop replace the text just yanked with the text killed before it
+func (in *Input) YankPop() {
+	if in.prevAction != actionYank || len(in.killRing) < 2 {
+		return
+	}
+
+	in.buffer = append(in.buffer[:in.yankStart], in.buffer[in.cursor:]...)
+	in.cursor = in.yankStart
+	in.yankIdx = (in.yankIdx + 1) % len(in.killRing)
+	in.insert(in.killRing[len(in.killRing)-1-in.yankIdx]...)
+	in.lastAction = actionYank
+}
+
+// YankLastArgument insert the last argument of the previous line in history
+func (in *Input) YankLastArgument() {
+	if len(in.history) == 0 {
+		return
+	}
+
+	args := strings.Fields(string(in.history[len(in.history)-1]))
+	if len(args) > 0 {
+		in.insert([]rune(args[len(args)-1])...)
+	}
+}
+
+// PreviousHistory replace the input with the previous line in history
+func (in *Input) PreviousHistory() {
+	in.moveInHistory(-1)
+}
+
+// NextHistory replace the input with the next line in history
+func (in *Input) NextHistory() {
+	in.moveInHistory(1)
+}
+
+func (in *Input) moveInHistory(step int) {
+	i := in.historyIdx + step
+	if i < 0 || i > len(in.history) {
+		return
 	}
 
-	if !hasSpace {
-		in.buffer = in.buffer[in.cursor:]
-		in.cursor = 0
+	if in.historyIdx == len(in.history) {
+		in.newLine = append([]rune(nil), in.buffer...)
+	}
+	in.historyIdx = i
+	if i == len(in.history) {
+		in.buffer = append(in.buffer[:0], in.newLine...)
+	} else {
+		in.buffer = append(in.buffer[:0], in.history[i]...)
 	}
+	in.cursor = len(in.buffer)
+}
+
+// Undo revert the last change of the input
+func (in *Input) Undo() {
+	if len(in.undoList) == 0 {
+		return
+	}
+
+	last := in.undoList[len(in.undoList)-1]
+	in.undoList = in.undoList[:len(in.undoList)-1]
+	in.buffer = append(in.buffer[:0], last.buffer...)
+	in.cursor = last.cursor
+}
+
+// insert add the characters at the cursor
+func (in *Input) insert(rs ...rune) {
+	for _, r := range rs {
+		in.AddCharacter(r)
+	}
+}
+
+// killRange delete the characters in [start, end) and save them in the kill ring,
+// the text killed by consecutive kills is accumulated like readline
+func (in *Input) killRange(start, end int) {
+	if start >= end {
+		return
+	}
+
+	text := append([]rune(nil), in.buffer[start:end]...)
+	in.buffer = append(in.buffer[:start], in.buffer[end:]...)
+	backward := end <= in.cursor
+	in.cursor = start
+	in.kill(text, backward)
+}
+
+func (in *Input) kill(text []rune, backward bool) {
+	switch {
+	case in.prevAction == actionKill && in.mode == EmacsMode && len(in.killRing) > 0:
+		last := len(in.killRing) - 1
+		if backward {
+			in.killRing[last] = append(text, in.killRing[last]...)
+		} else {
+			in.killRing[last] = append(in.killRing[last], text...)
+		}
+	default:
+		in.killRing = append(in.killRing, text)
+		if len(in.killRing) > maxKillRingSize {
+			in.killRing = in.killRing[1:]
+		}
+	}
+	in.lastAction = actionKill
 }
 
 // GoHead go to the beginning of the input
@@ -157,3 +343,7 @@ func isAlphabetOrNumeric(r rune) bool {
 
 	return false
 }
+
+func isBlank(r rune) bool {
+	return r == ' ' || r == '\t'
+}
@@ -0,0 +1,150 @@
+package term
+
+import (
+	"bytes"
+	"strings"
+)
+
+var (
+	// The pasted text is wrapped by them when the bracketed paste mode is on
+	pasteStart = []byte{asciiESC, asciiLeftSquareBracket, '2', '0', '0', '~'}
+	pasteEnd   = []byte{asciiESC, asciiLeftSquareBracket, '2', '0', '1', '~'}
+)
+
+// LineSplitter split the input of the terminal into the lines accepted by Enter, the newlines in bracketed paste
+// do not accept the line, since readline inserts them into the line
+type LineSplitter struct {
+	pasting bool
+}
+
+// Split return the input before the first Enter, the rest of the input after it and whether Enter is found
+func (s *LineSplitter) Split(input []byte) ([]byte, []byte, bool) {
+	for i := 0; i < len(input); i++ {
+		switch {
+		case bytes.HasPrefix(input[i:], pasteStart):
+			s.pasting = true
+			i += len(pasteStart) - 1
+		case bytes.HasPrefix(input[i:], pasteEnd):
+			s.pasting = false
+			i += len(pasteEnd) - 1
+		case !s.pasting && (input[i] == asciiCR || input[i] == asciiLF):
+			return input[:i], input[i+1:], true
+		}
+	}
+
+	return input, nil, false
+}
+
+// Pasting test whether the input is in bracketed paste
+func (s *LineSplitter) Pasting() bool {
+	return s.pasting
+}
+
+// StripPasteMarkers remove the markers of bracketed paste from the input, which only readline treats specially
+func StripPasteMarkers(input []byte) []byte {
+	return bytes.Replace(bytes.Replace(input, pasteStart, nil, -1), pasteEnd, nil, -1)
+}
+
+// Command denotes a logical command, the lines continued by backslashes and the here-documents belong to one command
+type Command struct {
+	Content string
+	Pasted  bool
+}
+
+// CommandGrouper group the lines executed by the shell into logical commands
+type CommandGrouper struct {
+	lines      []string
+	pasted     bool
+	delimiters []heredoc // the here-documents whose delimiters are not read yet
+}
+
+type heredoc struct {
+	delimiter string
+	stripTabs bool // <<- strips the leading tabs of the lines
+}
+
+// Add add the lines executed by Enter, which may contain newlines, and return the commands completed by them
+func (g *CommandGrouper) Add(content string, pasted bool) []Command {
+	var commands []Command
+	for _, line := range strings.Split(content, "\n") {
+		g.lines = append(g.lines, line)
+		g.pasted = g.pasted || pasted
+		if len(g.delimiters) > 0 {
+			d := g.delimiters[0]
+			if d.stripTabs {
+				line = strings.TrimLeft(line, "\t")
+			}
+			if line == d.delimiter {
+				g.delimiters = g.delimiters[1:]
+			}
+			if len(g.delimiters) > 0 {
+				continue
+			}
+		} else if g.delimiters = parseHeredocs(line); len(g.delimiters) > 0 || isContinued(line) {
+			continue
+		}
+
+		commands = append(commands, Command{
+			Content: strings.Join(g.lines, "\n"),
+			Pasted:  g.pasted,
+		})
+		g.lines = nil
+		g.pasted = false
+	}
+
+	return commands
+}
+
+// isContinued test whether the line ends with a backslash which is not escaped
+func isContinued(line string) bool {
+	n := len(line) - len(strings.TrimRight(line, "\\"))
+	return n%2 == 1
+}
+
+// parseHeredocs return the here-documents started in the line, such as cat <<EOF or cat <<-'EOF'
+func parseHeredocs(line string) []heredoc {
+	var (
+		heredocs []heredoc
+		quote    byte
+	)
+	for i := 0; i < len(line); i++ {
+		switch c := line[i]; {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			} else if c == '\\' && quote == '"' {
+				i++
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		case c == '\\':
+			i++
+		case c == '#' && (i == 0 || line[i-1] == ' ' || line[i-1] == '\t'):
+			return heredocs
+		case strings.HasPrefix(line[i:], "<<<"):
+			// Here-strings do not read the lines after
+			i += 2
+		case strings.HasPrefix(line[i:], "<<"):
+			i += 2
+			h := heredoc{}
+			if i < len(line) && line[i] == '-' {
+				h.stripTabs = true
+				i++
+			}
+			for i < len(line) && (line[i] == ' ' || line[i] == '\t') {
+				i++
+			}
+			start := i
+			for i < len(line) && !strings.ContainsRune(" \t;&|<>()", rune(line[i])) {
+				i++
+			}
+			// The quotes and backslashes in the word are removed from the delimiter
+			if h.delimiter = strings.NewReplacer("'", "", `"`, "", `\`, "").Replace(line[start:i]); h.delimiter != "" {
+				heredocs = append(heredocs, h)
+			}
+			i--
+		}
+	}
+
+	return heredocs
+}
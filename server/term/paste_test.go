@@ -0,0 +1,107 @@
+package term
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLineSplitterSplit(t *testing.T) {
+	cases := []struct {
+		inputs []string
+		want   []string
+	}{
+		{[]string{"ls\r", "pwd\rcat"}, []string{"ls", "pwd"}},
+		{[]string{"echo a\r\necho b\n"}, []string{"echo a", "", "echo b"}},
+		{[]string{"\x1b[200~ls\rpwd\r\x1b[201~\r"}, []string{"\x1b[200~ls\rpwd\r\x1b[201~"}},
+		{[]string{"\x1b[200~ls\r", "pwd\x1b[201~", "\rx\r"}, []string{"\x1b[200~ls\rpwd\x1b[201~", "x"}},
+	}
+
+	for _, c := range cases {
+		var (
+			s    LineSplitter
+			line []byte
+			got  []string
+		)
+		for _, input := range c.inputs {
+			for data := []byte(input); ; {
+				keys, rest, enter := s.Split(data)
+				line = append(line, keys...)
+				if !enter {
+					break
+				}
+
+				got = append(got, string(line))
+				line, data = nil, rest
+			}
+		}
+		if !reflect.DeepEqual(got, c.want) {
+			t.Errorf("s.Split(%q) == %q, want: %q.", c.inputs, got, c.want)
+		}
+	}
+}
+
+func TestStripPasteMarkers(t *testing.T) {
+	cases := []struct {
+		input string
+		want  string
+	}{
+		{"\x1b[200~hunter2\n\x1b[201~", "hunter2\n"},
+		{"ls\r", "ls\r"},
+		{"\x1b[200~a\x1b[201~\x1b[200~b\x1b[201~", "ab"},
+	}
+
+	for _, c := range cases {
+		if got := StripPasteMarkers([]byte(c.input)); string(got) != c.want {
+			t.Errorf("StripPasteMarkers(%q) == %q, want: %q.", c.input, got, c.want)
+		}
+	}
+}
+
+func TestCommandGrouperAdd(t *testing.T) {
+	cases := []struct {
+		lines  []string
+		pasted []bool
+		want   []Command
+	}{
+		{
+			[]string{"ls", "cd /tmp\npwd"},
+			[]bool{false, true},
+			[]Command{{"ls", false}, {"cd /tmp", true}, {"pwd", true}},
+		},
+		{
+			// Backslash continuations
+			[]string{"docker run \\", "  -it ubuntu", "echo a\\\\", "echo b"},
+			[]bool{false, false, false, false},
+			[]Command{{"docker run \\\n  -it ubuntu", false}, {"echo a\\\\", false}, {"echo b", false}},
+		},
+		{
+			// Here-documents, the quotes and tabs
+			[]string{"cat <<'EOF' > a.txt\n$HOME\nEOF", "cat <<-END && cat <<\"X\"", "\tfoo", "\tEND", "X", "grep a <<< EOF"},
+			[]bool{true, false, false, false, false, false},
+			[]Command{
+				{"cat <<'EOF' > a.txt\n$HOME\nEOF", true},
+				{"cat <<-END && cat <<\"X\"\n\tfoo\n\tEND\nX", false},
+				{"grep a <<< EOF", false},
+			},
+		},
+		{
+			// The redirections quoted or commented are not here-documents
+			[]string{"echo '<<EOF' # <<END", "echo \"a\\\"<<b\""},
+			[]bool{false, false},
+			[]Command{{"echo '<<EOF' # <<END", false}, {"echo \"a\\\"<<b\"", false}},
+		},
+	}
+
+	for _, c := range cases {
+		var (
+			g   CommandGrouper
+			got []Command
+		)
+		for i, line := range c.lines {
+			got = append(got, g.Add(line, c.pasted[i])...)
+		}
+		if !reflect.DeepEqual(got, c.want) {
+			t.Errorf("g.Add(%q) == %+v, want: %+v.", c.lines, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,525 @@
+package term
+
+import (
+	"bytes"
+	"strings"
+)
+
+// EditingMode denotes the editing mode of readline
+type EditingMode int
+
+const (
+	// EmacsMode is the default editing mode of readline
+	EmacsMode EditingMode = iota
+	// ViMode is the editing mode turned on by "set -o vi"
+	ViMode
+)
+
+const maxKillRingSize = 32
+
+// The special keys sent by the terminal as escape sequences
+const (
+	keyUnknown rune = -(iota + 1)
+	keyUp
+	keyDown
+	keyRight
+	keyLeft
+	keyHome
+	keyEnd
+	keyDelete
+	keyWordRight
+	keyWordLeft
+	keyPasteStart
+	keyPasteEnd
+)
+
+type action int
+
+const (
+	actionOther action = iota
+	actionInsert
+	actionKill
+	actionYank
+)
+
+// key denotes one key typed by the user
+type key struct {
+	r    rune // the character or the special key
+	meta bool // the character is typed with Alt, which is sent as ESC and the character
+	raw  []rune
+}
+
+type snapshot struct {
+	buffer []rune
+	cursor int
+}
+
+// search denotes the incremental search of history started by Ctrl-r or Ctrl-s
+type search struct {
+	forward    bool
+	pattern    []rune
+	index      int  // the index of the matched line in history
+	found      bool // some line in history matches the pattern
+	line       []rune
+	cursor     int
+	historyIdx int
+}
+
+// Edit apply the keys typed by the user to the input
+func (in *Input) Edit(input []byte) {
+	rs := bytes.Runes(input)
+	for i := 0; i < len(rs); {
+		k, n := parseKey(rs[i:], in.mode)
+		in.handleKey(k)
+		i += n
+	}
+}
+
+// Line return the content of the input
+func (in *Input) Line() string {
+	return string(in.buffer)
+}
+
+// Accept return the line accepted by Enter, add it to history and start a new line
+func (in *Input) Accept() string {
+	if in.search != nil {
+		in.endSearch()
+	}
+
+	line := string(in.buffer)
+	if line != "" {
+		in.history = append(in.history, []rune(line))
+	}
+	in.buffer = in.buffer[:0]
+	in.cursor = 0
+	in.historyIdx = len(in.history)
+	in.newLine = nil
+	in.undoList = nil
+	in.undoGroup = false
+	in.prevAction = actionOther
+	in.lastAction = actionOther
+	in.quoted = false
+	in.ctrlX = false
+	in.pasting = false
+	in.pasted = false
+	in.vi = viState{}
+	return line
+}
+
+// Pasted test whether some text of the line is pasted in the bracketed paste mode
+func (in *Input) Pasted() bool {
+	return in.pasted
+}
+
+// SetMode change the editing mode of the input
+func (in *Input) SetMode(mode EditingMode) {
+	in.mode = mode
+	in.vi = viState{}
+}
+
+// parseKey return the first key in rs and how many runes it takes
+func parseKey(rs []rune, mode EditingMode) (key, int) {
+	if rs[0] != asciiESC || len(rs) == 1 {
+		return key{r: rs[0], raw: rs[:1]}, 1
+	}
+
+	switch rs[1] {
+	case asciiLeftSquareBracket:
+		// CSI, the parameters and intermediate bytes are followed by the final byte
+		n := 2
+		for n < len(rs) && rs[n] >= 0x20 && rs[n] < 0x40 {
+			n++
+		}
+		if n == len(rs) {
+			return key{r: keyUnknown, raw: rs}, n
+		}
+		return key{r: csiKey(string(rs[2:n]), rs[n]), raw: rs[:n+1]}, n + 1
+	case asciiO:
+		// SS3, sent for the cursor keys in the application mode
+		if len(rs) > 2 {
+			return key{r: ss3Key(rs[2]), raw: rs[:3]}, 3
+		}
+	}
+
+	if mode == ViMode {
+		// ESC switches to the command mode in vi mode
+		return key{r: asciiESC, raw: rs[:1]}, 1
+	}
+
+	return key{r: rs[1], meta: true, raw: rs[:2]}, 2
+}
+
+func csiKey(params string, final rune) rune {
+	switch final {
+	case asciiA:
+		return keyUp
+	case asciiB:
+		return keyDown
+	case asciiC:
+		if params == "1;5" || params == "1;3" || params == "5" {
+			return keyWordRight
+		}
+		return keyRight
+	case asciiD:
+		if params == "1;5" || params == "1;3" || params == "5" {
+			return keyWordLeft
+		}
+		return keyLeft
+	case asciiH:
+		return keyHome
+	case asciiF:
+		return keyEnd
+	case '~':
+		switch params {
+		case "1", "7":
+			return keyHome
+		case "4", "8":
+			return keyEnd
+		case "3":
+			return keyDelete
+		case "200":
+			return keyPasteStart
+		case "201":
+			return keyPasteEnd
+		}
+	}
+
+	return keyUnknown
+}
+
+func ss3Key(r rune) rune {
+	switch r {
+	case asciiA:
+		return keyUp
+	case asciiB:
+		return keyDown
+	case asciiC:
+		return keyRight
+	case asciiD:
+		return keyLeft
+	case asciiH:
+		return keyHome
+	case asciiF:
+		return keyEnd
+	}
+
+	return keyUnknown
+}
+
+func (in *Input) handleKey(k key) {
+	before := snapshot{buffer: append([]rune(nil), in.buffer...), cursor: in.cursor}
+	in.prevAction, in.lastAction = in.lastAction, actionOther
+	undoing := false
+	switch {
+	case k.r == keyPasteStart:
+		// Bracketed paste, the text pasted is inserted literally until the end of the paste
+		if in.search != nil {
+			in.endSearch()
+		}
+		in.pasting = true
+		in.pasted = true
+	case k.r == keyPasteEnd:
+		in.pasting = false
+	case in.pasting:
+		for _, r := range k.raw {
+			if r == asciiCR {
+				// Readline inserts the newlines pasted instead of accepting the line
+				r = asciiLF
+			}
+			in.insert(r)
+		}
+		in.lastAction = actionInsert
+	case in.quoted:
+		// Ctrl-v, insert the next key literally
+		in.quoted = false
+		in.insert(k.raw...)
+		in.lastAction = actionInsert
+	case in.search != nil && in.handleSearchKey(k):
+	case in.mode == ViMode:
+		undoing = in.handleViKey(k)
+	default:
+		undoing = in.handleEmacsKey(k)
+	}
+
+	if undoing {
+		in.undoGroup = false
+		return
+	}
+
+	// The consecutive insertions are undone together
+	changed := string(before.buffer) != string(in.buffer)
+	if in.lastAction == actionInsert {
+		if changed && !in.undoGroup {
+			in.undoList = append(in.undoList, before)
+			in.undoGroup = true
+		}
+		return
+	}
+
+	in.undoGroup = false
+	if changed {
+		in.undoList = append(in.undoList, before)
+	}
+}
+
+// handleEmacsKey apply the key in emacs mode, and return whether it undoes the last change
+func (in *Input) handleEmacsKey(k key) bool {
+	if in.ctrlX {
+		// Ctrl-x Ctrl-u, undo the last change
+		in.ctrlX = false
+		if !k.meta && (k.r == asciiNAK || k.r == 'u') {
+			in.Undo()
+			return true
+		}
+		return false
+	}
+
+	if k.meta {
+		switch k.r {
+		case 'b':
+			// Alt-b, go back one word
+			in.GoBackOneWord()
+		case 'f':
+			// Alt-f, go forward one word
+			in.GoForwardOneWord()
+		case 'd':
+			// Alt-d, delete one word after the cursor
+			in.DeleteOneWordAfterCursor()
+		case asciiBS, asciiDEL:
+			// Alt-Backspace, delete one word before the cursor
+			in.DeleteBackOneWord()
+		case 'u':
+			in.UpcaseWord()
+		case 'l':
+			in.DowncaseWord()
+		case 'c':
+			in.CapitalizeWord()
+		case 'y':
+			// Alt-y, replace the text just yanked with the text killed before it
+			in.YankPop()
+		case '.', '_':
+			// Alt-., insert the last argument of the previous line
+			in.YankLastArgument()
+		case 'r':
+			// Alt-r, revert the line
+			if len(in.undoList) > 0 {
+				in.undoList = in.undoList[:1]
+				in.Undo()
+				return true
+			}
+		}
+		return false
+	}
+
+	if in.handleCommonKey(k) {
+		return false
+	}
+
+	switch k.r {
+	case asciiSOH:
+		// Ctrl-a, go to the beginning of the line
+		in.GoHead()
+	case asciiSTX:
+		// Ctrl-b, go back one character
+		in.GoBackOneCharacter()
+	case asciiENQ:
+		// Ctrl-e, go to the end of the line
+		in.GoEnd()
+	case asciiACK:
+		// Ctrl-f, go forward one character
+		in.GoForwardOneCharacter()
+	case asciiVT:
+		// Ctrl-k, delete characters after the cursor
+		in.DeleteCharactersAfterCursor()
+	case asciiSO:
+		// Ctrl-n, the next line in history
+		in.NextHistory()
+	case asciiDLE:
+		// Ctrl-p, the previous line in history
+		in.PreviousHistory()
+	case asciiCAN:
+		// Ctrl-x, the prefix of the next key
+		in.ctrlX = true
+	case asciiUS:
+		// Ctrl-_, undo the last change
+		in.Undo()
+		return true
+	case asciiBEL, asciiESC:
+		// Ctrl-g or a lone ESC does nothing out of the search
+	default:
+		in.insertKey(k)
+	}
+
+	return false
+}
+
+// handleCommonKey apply the key bound to the same command in emacs mode and the insert mode of vi
+func (in *Input) handleCommonKey(k key) bool {
+	switch k.r {
+	case asciiETX:
+		// Ctrl-c, discard the line
+		in.ClearLine()
+	case asciiEOT, keyDelete:
+		// Ctrl-d or Delete, delete the character under the cursor
+		in.DeleteCharacterUnderCursor()
+	case asciiFF:
+		// Ctrl-l, clear the screen
+	case asciiNAK:
+		// Ctrl-u, delete characters before the cursor
+		in.DeleteCharactersBeforeCursor()
+	case asciiETB:
+		// Ctrl-w, delete word before the cursor
+		in.DeleteOneWordBeforeCursor()
+	case asciiDC2, asciiDC3:
+		// Ctrl-r or Ctrl-s, search history incrementally
+		in.startSearch(k.r == asciiDC3)
+	case asciiDC4:
+		// Ctrl-t, transpose characters
+		in.TransposeCharacters()
+	case asciiSYN:
+		// Ctrl-v, insert the next key literally
+		in.quoted = true
+	case asciiEM:
+		// Ctrl-y, yank the text killed most recently
+		in.Yank()
+	case asciiBS, asciiDEL:
+		// Backspace, delete one character before the cursor
+		in.Backspace()
+	case keyUp:
+		in.PreviousHistory()
+	case keyDown:
+		in.NextHistory()
+	case keyRight:
+		in.GoForwardOneCharacter()
+	case keyLeft:
+		in.GoBackOneCharacter()
+	case keyHome:
+		in.GoHead()
+	case keyEnd:
+		in.GoEnd()
+	case keyWordRight:
+		in.GoForwardOneWord()
+	case keyWordLeft:
+		in.GoBackOneWord()
+	case keyUnknown:
+		// Function keys such as F1 and Insert do nothing
+	default:
+		return false
+	}
+
+	return true
+}
+
+func (in *Input) insertKey(k key) {
+	in.insert(k.r)
+	in.lastAction = actionInsert
+}
+
+func (in *Input) startSearch(forward bool) {
+	in.search = &search{
+		forward:    forward,
+		index:      in.historyIdx,
+		line:       append([]rune(nil), in.buffer...),
+		cursor:     in.cursor,
+		historyIdx: in.historyIdx,
+	}
+}
+
+// handleSearchKey apply the key to the search, and return false if the key ends the search and should be applied to the line
+func (in *Input) handleSearchKey(k key) bool {
+	s := in.search
+	switch {
+	case k.meta || k.r < 0:
+		in.endSearch()
+		return false
+	case k.r == asciiDC2 || k.r == asciiDC3:
+		// Ctrl-r or Ctrl-s again, search for the next match, or the last pattern if nothing is typed
+		s.forward = k.r == asciiDC3
+		if len(s.pattern) == 0 {
+			s.pattern = append(s.pattern, in.lastSearch...)
+		}
+		in.searchHistory(true)
+	case k.r == asciiBEL:
+		// Ctrl-g, abort the search and restore the line
+		in.buffer = append(in.buffer[:0], s.line...)
+		in.cursor = s.cursor
+		in.historyIdx = s.historyIdx
+		in.search = nil
+	case k.r == asciiBS || k.r == asciiDEL:
+		if len(s.pattern) > 0 {
+			s.pattern = s.pattern[:len(s.pattern)-1]
+			s.index = s.historyIdx
+			s.found = false
+			in.searchHistory(false)
+		}
+	case k.r == asciiESC:
+		// ESC ends the search, and switches to the command mode in vi mode
+		in.endSearch()
+		return in.mode != ViMode
+	case k.r >= ' ':
+		s.pattern = append(s.pattern, k.r)
+		in.searchHistory(false)
+	default:
+		// Other control keys end the search and edit the matched line
+		in.endSearch()
+		return false
+	}
+
+	return true
+}
+
+// searchHistory find the pattern in history from the current match, or the one after it if next is true
+func (in *Input) searchHistory(next bool) {
+	s := in.search
+	step := -1
+	if s.forward {
+		step = 1
+	}
+
+	i := s.index
+	if next || i == len(in.history) {
+		i += step
+	}
+	for ; len(s.pattern) > 0 && i >= 0 && i < len(in.history); i += step {
+		if next && string(in.history[i]) == string(in.buffer) {
+			// The duplicate lines are skipped when searching for the next match like readline
+			continue
+		}
+		if pos := indexRunes(string(in.history[i]), string(s.pattern), !s.forward); pos >= 0 {
+			in.buffer = append(in.buffer[:0], in.history[i]...)
+			in.cursor = pos
+			s.index = i
+			s.found = true
+			return
+		}
+	}
+
+	if !s.found {
+		// The history before the session is unknown to entry, the pattern is the best guess of the matched line
+		in.buffer = append(in.buffer[:0], s.pattern...)
+		in.cursor = len(in.buffer)
+	}
+}
+
+func (in *Input) endSearch() {
+	if len(in.search.pattern) > 0 {
+		in.lastSearch = in.search.pattern
+	}
+	if in.search.found {
+		in.historyIdx = in.search.index
+	}
+	in.search = nil
+}
+
+// indexRunes return the index in runes of the first or the last substr in s, or -1 if it is not found
+func indexRunes(s, substr string, last bool) int {
+	i := strings.Index(s, substr)
+	if last {
+		i = strings.LastIndex(s, substr)
+	}
+	if i < 0 {
+		return -1
+	}
+
+	return len([]rune(s[:i]))
+}
@@ -0,0 +1,163 @@
+package term
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestInputEdit(t *testing.T) {
+	cases := []struct {
+		in   string
+		want string
+	}{
+		// Kill and yank
+		{"hello world\x17\x01\x19", "worldhello "},
+		{"foo bar\x17\x17\x19", "foo bar"},
+		{"foo bar\x01\x0b\x19\x19", "foo barfoo bar"},
+		{"one two\x17three\x17\x19\x1by", "one two"},
+		{"abc\x02\x02\x0b\x15\x19", "abc"},
+		// Alt-d and Alt-Backspace delete words of letters and digits
+		{"echo foo-bar\x1bb\x1bb\x1bd", "echo -bar"},
+		{"echo foo-bar\x1b\x7f", "echo foo-"},
+		{"echo foo-bar\x1b\x08\x1b\x08baz", "echo baz"},
+		// Ctrl-t transposes characters
+		{"sl\x14", "ls"},
+		{"gti status\x01\x06\x06\x14", "git status"},
+		{"a\x01\x14", "a"},
+		// Home, End and Delete
+		{"world\x1b[Hhello \x1b[F!", "hello world!"},
+		{"world\x1b[1~hello \x1b[4~!", "hello world!"},
+		{"world\x1bOHhello \x1bOF!", "hello world!"},
+		{"rm -rf /\x1b[H\x1b[3~\x1b[3~\x1b[3~\x1b[3~\x1b[3~\x1b[3~ls", "ls /"},
+		// The cursor keys in the application mode
+		{"ct\x1bODa\x1bOC!", "cat!"},
+		// Ctrl-Left and Ctrl-Right move by words
+		{"echo world\x1b[1;5Dhello \x1b[1;5C!", "echo hello world!"},
+		// Unknown escape sequences are ignored
+		{"ls\x1b[2~\x1b[15~ -l", "ls -l"},
+		// The text pasted in the bracketed paste mode is inserted literally
+		{"echo \x1b[200~a\tb\x01\rc\x1b[201~\x01#", "#echo a\tb\x01\nc"},
+		// Case of words
+		{"echo hello world\x1bb\x1bb\x1bu\x1bc", "echo HELLO World"},
+		{"ECHO\x01\x1bl", "echo"},
+		// Ctrl-c discards the line
+		{"rm -rf /\x03ls", "ls"},
+		// Ctrl-v inserts the next key literally
+		{"echo a\x16\tb", "echo a\tb"},
+		// Undo
+		{"echo hello\x17world\x1f", "echo "},
+		{"echo hello\x17world\x1f\x1f", "echo hello"},
+		{"echo hello\x17world\x1f\x1f\x1f", ""},
+		{"ls\x18\x15", ""},
+		{"ls -l\x17\x17-a\x1br", ""},
+		// Lines that can not be found in history are kept
+		{"ls\x1b[A\x1b[B", "ls"},
+	}
+
+	for _, c := range cases {
+		in := NewInput()
+		in.Edit([]byte(c.in))
+		if got := in.Line(); got != c.want {
+			t.Errorf("in.Edit(%q), in.Line() == %q, want: %q.", c.in, got, c.want)
+		}
+	}
+}
+
+func TestInputEditVi(t *testing.T) {
+	cases := []struct {
+		in   string
+		want string
+	}{
+		{"ls -l\x1bbx", "ls l"},
+		{"echo hello world\x1bdb", "echo hello d"},
+		{"echo hello world\x1b0wcwbye\x1b$.", "echo bye world"},
+		{"echo hello world\x1b02dw", "world"},
+		{"echo hello world\x1b0d2w", "world"},
+		{"echo hello world\x1bFhD", "echo "},
+		{"echo hello world\x1b0fhC!", "ec!"},
+		{"echo hello world\x1bddils", "ls"},
+		{"echo hello world\x1bccls", "ls"},
+		{"echo hello world\x1b0dtw", "world"},
+		{"a.b.c\x1b0f.;x", "a.bc"},
+		{"a.b.c\x1bF.F.,x", "a.bc"},
+		{"foo bar\x1b0ywP", "foo foo bar"},
+		{"foo bar\x1bbdwbP", "barfoo "},
+		{"cat foo\x1b0rb~~", "BAt foo"},
+		{"git comit\x1bhhim", "git commit"},
+		{"ls\x1bIsudo \x1bA -l", "sudo ls -l"},
+		{"ls -l\x1b0ea -a", "ls -a -l"},
+		{"echo foo-bar\x1b0WD", "echo "},
+		{"echo foo-bar\x1b0wwD", "echo foo"},
+		{"echo foo\x1bbsb\x1b3|i-\x1bu", "echo boo"},
+		{"echo foo\x1bbcwbar\x1bu", "echo foo"},
+		{"ls\x1bxxxx", ""},
+		{"ls\x1b[D\x1b[Da\x1b[C\x1b[3~", "al"},
+		{"rm -rf /\x03ls", "ls"},
+	}
+
+	for _, c := range cases {
+		in := NewInput()
+		in.SetMode(ViMode)
+		in.Edit([]byte(c.in))
+		if got := in.Line(); got != c.want {
+			t.Errorf("in.Edit(%q) in vi mode, in.Line() == %q, want: %q.", c.in, got, c.want)
+		}
+	}
+}
+
+func TestInputAccept(t *testing.T) {
+	cases := []struct {
+		mode   EditingMode
+		inputs []string
+		want   []string
+	}{
+		{
+			EmacsMode,
+			[]string{"ls -l", "\x1b[A\x1b[A\x1b[B", "cat foo", "\x10\x10 -a"},
+			[]string{"ls -l", "", "cat foo", "ls -l -a"},
+		},
+		{
+			// Reverse search, Ctrl-r again for the older match
+			EmacsMode,
+			[]string{"tail -f a.log", "cat b.log", "\x12log", "\x12log\x12", "\x12ta\x05 | grep x"},
+			[]string{"tail -f a.log", "cat b.log", "cat b.log", "tail -f a.log", "tail -f a.log | grep x"},
+		},
+		{
+			// Ctrl-g aborts the search, Backspace shortens the pattern, and the lines not in history are guessed
+			EmacsMode,
+			[]string{"make test", "vim x\x12mak\x07", "\x12kx\x7f\x1b[C\x1b[C\x1b[Cgo ", "\x12uptime"},
+			[]string{"make test", "vim x", "make go test", "uptime"},
+		},
+		{
+			// Ctrl-r without typing repeats the last search
+			EmacsMode,
+			[]string{"echo 1", "echo 2", "\x12ech", "\x12\x12\x12"},
+			[]string{"echo 1", "echo 2", "echo 2", "echo 1"},
+		},
+		{
+			// The kill ring and the last argument are kept across lines
+			EmacsMode,
+			[]string{"vim /etc/hosts\x01\x1bdcat", "ls \x19 \x1b.", "\x1b[A\x17\x17\x19 \x19"},
+			[]string{"cat /etc/hosts", "ls vim /etc/hosts", "ls vim /etc/hosts vim /etc/hosts"},
+		},
+		{
+			// History in vi mode, which returns to the insert mode for each line
+			ViMode,
+			[]string{"ls -l", "cat foo", "\x1bkkA -a", "echo\x1bk", "\x12cat\x1b0cwless\x1b"},
+			[]string{"ls -l", "cat foo", "ls -l -a", "ls -l -a", "less foo"},
+		},
+	}
+
+	for _, c := range cases {
+		in := NewInput()
+		in.SetMode(c.mode)
+		var got []string
+		for _, input := range c.inputs {
+			in.Edit([]byte(input))
+			got = append(got, in.Accept())
+		}
+		if !reflect.DeepEqual(got, c.want) {
+			t.Errorf("in.Edit(%q) and in.Accept() == %q, want: %q.", c.inputs, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,650 @@
+package term
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/width"
+)
+
+const (
+	// MaxWidth and MaxHeight limit the size of the screen, which is requested by the clients
+	MaxWidth  = 1000
+	MaxHeight = 500
+
+	tabWidth = 8
+	// wideTail is the cell occupied by the right half of a wide character
+	wideTail = -1
+)
+
+type parserState int
+
+const (
+	stateGround parserState = iota
+	stateEscape
+	stateEscapeCharset
+	stateCSI
+	stateOSC
+	stateString
+	stateStringEscape
+)
+
+// Screen is a VT100/xterm screen model fed by the output of the terminal, only the text and the cursor are modeled,
+// the attributes such as colors are ignored
+type Screen struct {
+	width    int
+	height   int
+	lines    []screenLine
+	primary  []screenLine // the primary screen while the alternate screen is shown
+	row      int
+	col      int
+	wrapNext bool
+	autoWrap bool
+	top      int
+	bottom   int
+	savedRow int
+	savedCol int
+
+	state    parserState
+	params   []int
+	private  byte
+	pending  []byte // the incomplete UTF-8 sequence
+	lastRune rune
+}
+
+type screenLine struct {
+	cells   []rune
+	wrapped bool // the line continues on the next line because of auto wrap
+}
+
+// NewScreen return an initialized *Screen of the size
+func NewScreen(width, height int) *Screen {
+	s := &Screen{}
+	s.reset(width, height)
+	return s
+}
+
+func (s *Screen) reset(width, height int) {
+	if width <= 0 {
+		width = 80
+	}
+	if height <= 0 {
+		height = 24
+	}
+	width, height = minInt(width, MaxWidth), minInt(height, MaxHeight)
+
+	*s = Screen{
+		width:    width,
+		height:   height,
+		lines:    newLines(width, height),
+		autoWrap: true,
+		bottom:   height - 1,
+	}
+}
+
+func newLines(width, height int) []screenLine {
+	lines := make([]screenLine, height)
+	for i := range lines {
+		lines[i] = screenLine{cells: make([]rune, width)}
+	}
+	return lines
+}
+
+// Size return the width and the height of the screen
+func (s *Screen) Size() (int, int) {
+	return s.width, s.height
+}
+
+// Cursor return the row and the column of the cursor, both start from 0
+func (s *Screen) Cursor() (int, int) {
+	return s.row, s.col
+}
+
+// AlternateScreen return whether the alternate screen of full screen programs such as vim is shown
+func (s *Screen) AlternateScreen() bool {
+	return s.primary != nil
+}
+
+// Resize change the size of the screen, the lines above are dropped if the cursor would be out of the screen,
+// the size is limited by MaxWidth and MaxHeight
+func (s *Screen) Resize(width, height int) {
+	if width <= 0 || height <= 0 {
+		return
+	}
+
+	width, height = minInt(width, MaxWidth), minInt(height, MaxHeight)
+	if width == s.width && height == s.height {
+		return
+	}
+
+	resize := func(lines []screenLine, row int) []screenLine {
+		if row >= height {
+			lines = lines[row-height+1:]
+		}
+		for len(lines) < height {
+			lines = append(lines, screenLine{cells: make([]rune, width)})
+		}
+		lines = lines[:height]
+		for i := range lines {
+			cells := make([]rune, width)
+			copy(cells, lines[i].cells)
+			lines[i].cells = cells
+		}
+		return lines
+	}
+
+	s.lines = resize(s.lines, s.row)
+	s.row = minInt(s.row, height-1)
+	if s.primary != nil {
+		s.primary = resize(s.primary, 0)
+	}
+	s.width, s.height = width, height
+	s.top, s.bottom = 0, height-1
+	s.col = minInt(s.col, width-1)
+	s.wrapNext = false
+}
+
+// Write implement io.Writer, data is the output of the terminal
+func (s *Screen) Write(data []byte) (int, error) {
+	for _, b := range data {
+		s.feed(b)
+	}
+	return len(data), nil
+}
+
+func (s *Screen) feed(b byte) {
+	switch s.state {
+	case stateEscape:
+		s.escape(b)
+		return
+	case stateEscapeCharset:
+		s.state = stateGround
+		return
+	case stateCSI:
+		s.csi(b)
+		return
+	case stateOSC, stateString:
+		switch b {
+		case asciiBEL:
+			if s.state == stateOSC {
+				s.state = stateGround
+			}
+		case asciiESC:
+			s.state = stateStringEscape
+		}
+		return
+	case stateStringEscape:
+		// ESC \ is the string terminator, the others start a new sequence
+		s.state = stateGround
+		if b != '\\' {
+			s.feed(asciiESC)
+			s.feed(b)
+		}
+		return
+	}
+
+	if len(s.pending) > 0 || b >= utf8.RuneSelf {
+		s.pending = append(s.pending, b)
+		if !utf8.FullRune(s.pending) {
+			return
+		}
+
+		r, _ := utf8.DecodeRune(s.pending)
+		s.pending = s.pending[:0]
+		s.put(r)
+		return
+	}
+
+	switch b {
+	case asciiESC:
+		s.state = stateEscape
+	case asciiBS:
+		if s.col > 0 {
+			s.col--
+		}
+		s.wrapNext = false
+	case asciiHT:
+		s.col = minInt((s.col/tabWidth+1)*tabWidth, s.width-1)
+		s.wrapNext = false
+	case '\n', asciiVT, asciiFF:
+		s.lineFeed()
+	case asciiCR:
+		s.col = 0
+		s.wrapNext = false
+	default:
+		if b >= ' ' && b != asciiDEL {
+			s.put(rune(b))
+		}
+	}
+}
+
+func (s *Screen) escape(b byte) {
+	s.state = stateGround
+	switch b {
+	case '[':
+		s.state = stateCSI
+		s.params = s.params[:0]
+		s.private = 0
+	case ']':
+		s.state = stateOSC
+	case 'P', 'X', '^', '_':
+		s.state = stateString
+	case '(', ')', '*', '+', '-', '.', '/', '#', '%':
+		s.state = stateEscapeCharset
+	case '7':
+		s.saveCursor()
+	case '8':
+		s.restoreCursor()
+	case 'D':
+		s.lineFeed()
+	case 'E':
+		s.col = 0
+		s.lineFeed()
+	case 'M':
+		s.reverseIndex()
+	case 'c':
+		s.reset(s.width, s.height)
+	}
+}
+
+func (s *Screen) csi(b byte) {
+	switch {
+	case b >= '0' && b <= '9':
+		if len(s.params) == 0 {
+			s.params = append(s.params, 0)
+		}
+		s.params[len(s.params)-1] = s.params[len(s.params)-1]*10 + int(b-'0')
+		return
+	case b == ';' || b == ':':
+		if len(s.params) == 0 {
+			s.params = append(s.params, 0)
+		}
+		s.params = append(s.params, 0)
+		return
+	case b >= '<' && b <= '?':
+		s.private = b
+		return
+	case b >= ' ' && b <= '/':
+		// Intermediate bytes, none of the supported sequences has them
+		return
+	case b < ' ':
+		// Control characters are executed in the middle of a sequence
+		state := s.state
+		s.state = stateGround
+		s.feed(b)
+		if s.state == stateGround {
+			s.state = state
+		}
+		return
+	}
+
+	s.state = stateGround
+	if s.private != 0 {
+		if s.private == '?' && (b == 'h' || b == 'l') {
+			s.setPrivateModes(b == 'h')
+		}
+		return
+	}
+
+	n := s.param(0, 1)
+	switch b {
+	case '@':
+		s.insertCells(n)
+	case 'A':
+		s.moveTo(s.row-n, s.col, true)
+	case 'B', 'e':
+		s.moveTo(s.row+n, s.col, true)
+	case 'C', 'a':
+		s.moveTo(s.row, s.col+n, false)
+	case 'D':
+		s.moveTo(s.row, s.col-n, false)
+	case 'E':
+		s.moveTo(s.row+n, 0, true)
+	case 'F':
+		s.moveTo(s.row-n, 0, true)
+	case 'G', '`':
+		s.moveTo(s.row, n-1, false)
+	case 'H', 'f':
+		s.moveTo(n-1, s.param(1, 1)-1, false)
+	case 'd':
+		s.moveTo(n-1, s.col, false)
+	case 'J':
+		s.eraseDisplay(s.param(0, 0))
+	case 'K':
+		s.eraseLine(s.param(0, 0))
+	case 'L':
+		s.insertLines(n)
+	case 'M':
+		s.deleteLines(n)
+	case 'P':
+		s.deleteCells(n)
+	case 'X':
+		s.clearCells(s.row, s.col, minInt(s.col+n, s.width))
+		s.wrapNext = false
+	case 'S':
+		s.scrollUp(s.top, s.bottom, n)
+	case 'T':
+		s.scrollDown(s.top, s.bottom, n)
+	case 'b':
+		if s.lastRune != 0 {
+			for i := 0; i < n; i++ {
+				s.put(s.lastRune)
+			}
+		}
+	case 'r':
+		top, bottom := s.param(0, 1)-1, s.param(1, s.height)-1
+		if top < bottom && bottom < s.height {
+			s.top, s.bottom = top, bottom
+			s.moveTo(0, 0, false)
+		}
+	case 's':
+		s.saveCursor()
+	case 'u':
+		s.restoreCursor()
+	}
+}
+
+// param return the ith parameter, or def if it is omitted or 0
+func (s *Screen) param(i, def int) int {
+	if i >= len(s.params) || s.params[i] == 0 {
+		return def
+	}
+
+	return s.params[i]
+}
+
+func (s *Screen) setPrivateModes(on bool) {
+	for _, mode := range s.params {
+		switch mode {
+		case 7:
+			s.autoWrap = on
+		case 47, 1047, 1049:
+			if mode == 1049 && on {
+				s.saveCursor()
+			}
+			s.switchScreen(on)
+			if mode == 1049 && !on {
+				s.restoreCursor()
+			}
+		}
+	}
+}
+
+func (s *Screen) switchScreen(alternate bool) {
+	switch {
+	case alternate && s.primary == nil:
+		s.primary = s.lines
+		s.lines = newLines(s.width, s.height)
+	case !alternate && s.primary != nil:
+		s.lines = s.primary
+		s.primary = nil
+	}
+}
+
+func (s *Screen) saveCursor() {
+	s.savedRow, s.savedCol = s.row, s.col
+}
+
+func (s *Screen) restoreCursor() {
+	s.moveTo(s.savedRow, s.savedCol, false)
+}
+
+// moveTo move the cursor, it stays in the scroll region if inRegion is true and it is in the region
+func (s *Screen) moveTo(row, col int, inRegion bool) {
+	top, bottom := 0, s.height-1
+	if inRegion && s.row >= s.top && s.row <= s.bottom {
+		top, bottom = s.top, s.bottom
+	}
+	s.row = maxInt(top, minInt(row, bottom))
+	s.col = maxInt(0, minInt(col, s.width-1))
+	s.wrapNext = false
+}
+
+func (s *Screen) put(r rune) {
+	w := runeWidth(r)
+	if w == 0 {
+		return
+	}
+
+	s.lastRune = r
+	if s.wrapNext || (w == 2 && s.col == s.width-1) {
+		if s.autoWrap {
+			s.lines[s.row].wrapped = true
+			s.col = 0
+			s.lineFeed()
+		} else if w == 2 {
+			return
+		}
+	}
+	s.wrapNext = false
+
+	cells := s.lines[s.row].cells
+	s.clearCells(s.row, s.col, s.col+w)
+	cells[s.col] = r
+	if w == 2 {
+		cells[s.col+1] = wideTail
+	}
+	if s.col+w < s.width {
+		s.col += w
+	} else {
+		s.col = s.width - 1
+		s.wrapNext = s.autoWrap
+	}
+}
+
+func (s *Screen) lineFeed() {
+	switch {
+	case s.row == s.bottom:
+		s.scrollUp(s.top, s.bottom, 1)
+	case s.row < s.height-1:
+		s.row++
+	}
+	s.wrapNext = false
+}
+
+func (s *Screen) reverseIndex() {
+	switch {
+	case s.row == s.top:
+		s.scrollDown(s.top, s.bottom, 1)
+	case s.row > 0:
+		s.row--
+	}
+	s.wrapNext = false
+}
+
+// scrollUp move the lines between top and bottom up by n lines, the blank lines are added at the bottom
+func (s *Screen) scrollUp(top, bottom, n int) {
+	n = minInt(n, bottom-top+1)
+	copy(s.lines[top:bottom+1], s.lines[top+n:bottom+1])
+	for i := bottom - n + 1; i <= bottom; i++ {
+		s.lines[i] = screenLine{cells: make([]rune, s.width)}
+	}
+}
+
+// scrollDown move the lines between top and bottom down by n lines, the blank lines are added at the top
+func (s *Screen) scrollDown(top, bottom, n int) {
+	n = minInt(n, bottom-top+1)
+	copy(s.lines[top+n:bottom+1], s.lines[top:bottom+1-n])
+	for i := top; i < top+n; i++ {
+		s.lines[i] = screenLine{cells: make([]rune, s.width)}
+	}
+}
+
+func (s *Screen) insertLines(n int) {
+	if s.row >= s.top && s.row <= s.bottom {
+		s.scrollDown(s.row, s.bottom, n)
+		s.col = 0
+		s.wrapNext = false
+	}
+}
+
+func (s *Screen) deleteLines(n int) {
+	if s.row >= s.top && s.row <= s.bottom {
+		s.scrollUp(s.row, s.bottom, n)
+		s.col = 0
+		s.wrapNext = false
+	}
+}
+
+func (s *Screen) insertCells(n int) {
+	cells := s.lines[s.row].cells
+	n = minInt(n, s.width-s.col)
+	copy(cells[s.col+n:], cells[s.col:])
+	s.clearCells(s.row, s.col, s.col+n)
+	s.fixWideTail(s.row)
+	s.wrapNext = false
+}
+
+func (s *Screen) deleteCells(n int) {
+	cells := s.lines[s.row].cells
+	n = minInt(n, s.width-s.col)
+	if s.col > 0 && cells[s.col] == wideTail {
+		cells[s.col-1] = 0
+	}
+	copy(cells[s.col:], cells[s.col+n:])
+	for i := s.width - n; i < s.width; i++ {
+		cells[i] = 0
+	}
+	if cells[s.col] == wideTail {
+		cells[s.col] = 0
+	}
+	s.wrapNext = false
+}
+
+// clearCells erase the cells in [from, to) of the row, the wide characters partly erased are erased entirely
+func (s *Screen) clearCells(row, from, to int) {
+	cells := s.lines[row].cells
+	if from > 0 && from < s.width && cells[from] == wideTail {
+		cells[from-1] = 0
+	}
+	if to < s.width && cells[to] == wideTail {
+		cells[to] = 0
+	}
+	for i := from; i < to; i++ {
+		cells[i] = 0
+	}
+}
+
+// fixWideTail erase the right half of the wide character which is pushed to the last column
+func (s *Screen) fixWideTail(row int) {
+	cells := s.lines[row].cells
+	if last := cells[s.width-1]; last != 0 && last != wideTail && runeWidth(last) == 2 {
+		cells[s.width-1] = 0
+	}
+}
+
+func (s *Screen) eraseDisplay(mode int) {
+	switch mode {
+	case 0:
+		s.eraseLine(0)
+		for i := s.row + 1; i < s.height; i++ {
+			s.lines[i] = screenLine{cells: make([]rune, s.width)}
+		}
+	case 1:
+		s.eraseLine(1)
+		for i := 0; i < s.row; i++ {
+			s.lines[i] = screenLine{cells: make([]rune, s.width)}
+		}
+	case 2, 3:
+		s.lines = newLines(s.width, s.height)
+	}
+	s.wrapNext = false
+}
+
+func (s *Screen) eraseLine(mode int) {
+	switch mode {
+	case 0:
+		s.clearCells(s.row, s.col, s.width)
+		s.lines[s.row].wrapped = false
+	case 1:
+		s.clearCells(s.row, 0, s.col+1)
+	case 2:
+		s.lines[s.row] = screenLine{cells: make([]rune, s.width)}
+	}
+	s.wrapNext = false
+}
+
+// Lines return the text of every line of the screen, the trailing spaces are trimmed
+func (s *Screen) Lines() []string {
+	lines := make([]string, s.height)
+	for i := range s.lines {
+		lines[i] = strings.TrimRight(s.text(i, 0, s.width), " ")
+	}
+	return lines
+}
+
+// String return the text of the screen, the trailing blank lines are trimmed
+func (s *Screen) String() string {
+	return strings.TrimRight(strings.Join(s.Lines(), "\n"), "\n")
+}
+
+// CursorLine return the text of the line where the cursor is, the lines joined by auto wrap are one line,
+// and the text before the cursor in it
+func (s *Screen) CursorLine() (string, string) {
+	first, last := s.row, s.row
+	for first > 0 && s.lines[first-1].wrapped {
+		first--
+	}
+	for last < s.height-1 && s.lines[last].wrapped {
+		last++
+	}
+
+	var line, beforeCursor strings.Builder
+	for i := first; i <= last; i++ {
+		if i == s.row {
+			beforeCursor.WriteString(line.String())
+			beforeCursor.WriteString(s.text(i, 0, s.col))
+		}
+		line.WriteString(s.text(i, 0, s.width))
+	}
+	return strings.TrimRight(line.String(), " "), beforeCursor.String()
+}
+
+// text return the text of the cells in [from, to) of the row, the blank cells are spaces
+func (s *Screen) text(row, from, to int) string {
+	var b strings.Builder
+	for _, r := range s.lines[row].cells[from:to] {
+		switch r {
+		case wideTail:
+		case 0:
+			b.WriteByte(' ')
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// runeWidth return how many cells the character takes, 0 for the combining ones which are not modeled
+func runeWidth(r rune) int {
+	switch {
+	case r < ' ' || r == asciiDEL:
+		return 0
+	case unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf):
+		return 0
+	}
+
+	switch width.LookupRune(r).Kind() {
+	case width.EastAsianWide, width.EastAsianFullwidth:
+		return 2
+	default:
+		return 1
+	}
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+
+	return b
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+
+	return b
+}
@@ -0,0 +1,135 @@
+package term
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestScreenWrite(t *testing.T) {
+	cases := []struct {
+		width, height int
+		outputs       []string
+		wantLines     []string
+		wantRow       int
+		wantCol       int
+	}{
+		{
+			10, 3,
+			[]string{"$ ls\r\na b\r\n$ "},
+			[]string{"$ ls", "a b", "$"},
+			2, 2,
+		},
+		{
+			// Auto wrap, then scroll
+			5, 2,
+			[]string{"abcdefgh\r\nij"},
+			[]string{"fgh", "ij"},
+			1, 2,
+		},
+		{
+			// Readline erases the line and redraws it
+			10, 2,
+			[]string{"$ cat foo", "\b\b\b\033[Kbar\r\033[C\033[C\033[1@x"},
+			[]string{"$ xcat bar", ""},
+			0, 3,
+		},
+		{
+			// The escape sequences split across the writes, and OSC is ignored
+			10, 2,
+			[]string{"\033]0;ti", "tle\a\033[2", ";3Hok\033", "[1;1Hx\033[2K"},
+			[]string{"", "  ok"},
+			0, 1,
+		},
+		{
+			// Wide characters and multi-byte sequences split across the writes
+			6, 2,
+			[]string{"你\xe5", "\xa5\xbdab", "\r\033[2C\033[P\rx"},
+			[]string{"x  ab", ""},
+			0, 1,
+		},
+		{
+			// The alternate screen is restored
+			10, 2,
+			[]string{"$ vim\r\n\033[?1049h\033[2Jfoo\033[?1049l"},
+			[]string{"$ vim", ""},
+			1, 0,
+		},
+		{
+			// The scroll region
+			5, 4,
+			[]string{"a\r\nb\r\nc\r\nd\033[2;3r\033[3;1H\n\n\033M\033M\033[Lx"},
+			[]string{"a", "x", "", "d"},
+			1, 1,
+		},
+	}
+
+	for _, c := range cases {
+		s := NewScreen(c.width, c.height)
+		for _, output := range c.outputs {
+			s.Write([]byte(output))
+		}
+		row, col := s.Cursor()
+		if lines := s.Lines(); !reflect.DeepEqual(lines, c.wantLines) || row != c.wantRow || col != c.wantCol {
+			t.Errorf("s.Write(%q), s.Lines() == %q, cursor: (%d, %d), want: %q, cursor: (%d, %d).", c.outputs, lines, row, col, c.wantLines, c.wantRow, c.wantCol)
+		}
+	}
+}
+
+func TestScreenCursorLine(t *testing.T) {
+	cases := []struct {
+		width            int
+		output           string
+		wantLine         string
+		wantBeforeCursor string
+	}{
+		{10, "$ ls -l", "$ ls -l", "$ ls -l"},
+		{10, "foo\r\n$ ls -l\b\b\b", "$ ls -l", "$ ls"},
+		{5, "$ echo hello", "$ echo hello", "$ echo hello"},
+		{5, "$ echo hello\033[A", "$ echo hello", "$ echo "},
+		{5, "$ abcde\r\n$ x", "$ x", "$ x"},
+	}
+
+	for _, c := range cases {
+		s := NewScreen(c.width, 5)
+		s.Write([]byte(c.output))
+		if line, beforeCursor := s.CursorLine(); line != c.wantLine || beforeCursor != c.wantBeforeCursor {
+			t.Errorf("s.Write(%q), s.CursorLine() == %q, %q, want: %q, %q.", c.output, line, beforeCursor, c.wantLine, c.wantBeforeCursor)
+		}
+	}
+}
+
+func TestScreenResize(t *testing.T) {
+	s := NewScreen(10, 3)
+	s.Write([]byte("a\r\nb\r\nc"))
+	s.Resize(4, 2)
+	row, col := s.Cursor()
+	if lines := s.Lines(); !reflect.DeepEqual(lines, []string{"b", "c"}) || row != 1 || col != 1 {
+		t.Errorf("s.Resize(4, 2), s.Lines() == %q, cursor: (%d, %d), want: %q, cursor: (1, 1).", lines, row, col, []string{"b", "c"})
+	}
+}
+
+func TestScreenSizeLimit(t *testing.T) {
+	cases := []struct {
+		width      int
+		height     int
+		wantWidth  int
+		wantHeight int
+	}{
+		{132, 43, 132, 43},
+		{65535, 65535, MaxWidth, MaxHeight},
+		{MaxWidth + 1, 10, MaxWidth, 10},
+		{0, -1, 132, 43},
+	}
+
+	for _, c := range cases {
+		s := NewScreen(132, 43)
+		s.Resize(c.width, c.height)
+		if width, height := s.Size(); width != c.wantWidth || height != c.wantHeight {
+			t.Errorf("s.Resize(%d, %d), s.Size() == %d, %d, want: %d, %d.", c.width, c.height, width, height, c.wantWidth, c.wantHeight)
+		}
+
+		if width, height := NewScreen(c.width, c.height).Size(); width > MaxWidth || height > MaxHeight {
+			t.Errorf("NewScreen(%d, %d).Size() == %d, %d, want no more than %d, %d.", c.width, c.height, width, height, MaxWidth, MaxHeight)
+		}
+	}
+}
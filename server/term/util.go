@@ -7,22 +7,36 @@ import (
 const (
 	asciiSOH               = 1
 	asciiSTX               = 2
+	asciiETX               = 3
 	asciiEOT               = 4
 	asciiENQ               = 5
 	asciiACK               = 6
 	asciiBEL               = 7
 	asciiBS                = 8
 	asciiHT                = 9
+	asciiLF                = 10
 	asciiVT                = 11
 	asciiFF                = 12
 	asciiCR                = 13
+	asciiSO                = 14
+	asciiDLE               = 16
+	asciiDC2               = 18
+	asciiDC3               = 19
+	asciiDC4               = 20
 	asciiNAK               = 21
+	asciiSYN               = 22
 	asciiETB               = 23
+	asciiCAN               = 24
+	asciiEM                = 25
 	asciiESC               = 27 // Control sequence prefix
+	asciiUS                = 31
 	asciiA                 = 65
 	asciiB                 = 66
 	asciiC                 = 67
 	asciiD                 = 68
+	asciiF                 = 70
+	asciiH                 = 72
+	asciiO                 = 79 // SS3 prefix after ESC
 	asciiLeftSquareBracket = 91 // Control sequence prefix
 	asciib                 = 98
 	asciif                 = 102
@@ -34,65 +48,17 @@ var (
 	UpArrow = []byte{asciiESC, asciiLeftSquareBracket, asciiA}
 	// DownArrow denotes down arrow
 	DownArrow = []byte{asciiESC, asciiLeftSquareBracket, asciiB}
+
+	// The arrows are sent with SS3 when the terminal is in the application cursor mode, which readline may turn on
+	applicationUpArrow   = []byte{asciiESC, asciiO, asciiA}
+	applicationDownArrow = []byte{asciiESC, asciiO, asciiB}
 )
 
-// EscapeInput escape special characters such as SOH, ENQ and DEL in user input
+// EscapeInput escape special characters such as SOH, ENQ and DEL in user input, see Input.Edit for the keys supported
 func EscapeInput(input []byte) []byte {
-	rs := bytes.Runes(input)
 	in := NewInput()
-	for i := 0; i < len(rs); i++ {
-		switch {
-		case rs[i] == asciiSOH:
-			// Ctrl-a, go to the beginning of the line
-			in.GoHead()
-		case rs[i] == asciiSTX:
-			// Ctrl-b, go back one character
-			in.GoBackOneCharacter()
-		case rs[i] == asciiEOT:
-			// Ctrl-d, delete the character under the cursor
-			in.DeleteCharacterUnderCursor()
-		case rs[i] == asciiENQ:
-			// Ctrl-e, go to the end of the line
-			in.GoEnd()
-		case rs[i] == asciiACK:
-			// Ctrl-f, go forward one character
-			in.GoForwardOneCharacter()
-		case rs[i] == asciiVT:
-			// Ctrl-k, delete characters after the cursor
-			in.DeleteCharactersAfterCursor()
-		case rs[i] == asciiFF:
-			// Ctrl-l, clear the screen
-		case rs[i] == asciiNAK:
-			// Ctrl-u, delete characters before the cursor
-			in.DeleteCharactersBeforeCursor()
-		case rs[i] == asciiETB:
-			// Ctrl-w, delete word before the cursor
-			in.DeleteOneWordBeforeCursor()
-		case rs[i] == asciiESC && i < len(rs)-2 && rs[i+1] == asciiLeftSquareBracket && rs[i+2] == asciiC:
-			// Right arrow, go forward one character
-			in.GoForwardOneCharacter()
-			i += 2
-		case rs[i] == asciiESC && i < len(rs)-2 && rs[i+1] == asciiLeftSquareBracket && rs[i+2] == asciiD:
-			// Left arrow: go back one character
-			in.GoBackOneCharacter()
-			i += 2
-		case rs[i] == asciiESC && i < len(rs)-1 && rs[i+1] == asciib:
-			// Alt-b, go back one word
-			in.GoBackOneWord()
-			i++
-		case rs[i] == asciiESC && i < len(rs)-1 && rs[i+1] == asciif:
-			// Alt-f, go forward one word
-			in.GoForwardOneWord()
-			i++
-		case rs[i] == asciiBS || rs[i] == asciiDEL:
-			// Backspace, delete one character before the cursor
-			in.Backspace()
-		default:
-			in.AddCharacter(rs[i])
-		}
-	}
-
-	return []byte(string(in.buffer))
+	in.Edit(input)
+	return []byte(in.Line())
 }
 
 // EscapeTabCompletion escape tab completion
@@ -121,32 +87,12 @@ func EscapeHistoryCommand(input []byte) []byte {
 
 // HasUpArrowSuffix test whether input has up arrow suffix
 func HasUpArrowSuffix(input []byte) bool {
-	if len(input) < 3 {
-		return false
-	}
-
-	for i, b := range UpArrow {
-		if input[len(input)-len(UpArrow)+i] != b {
-			return false
-		}
-	}
-
-	return true
+	return bytes.HasSuffix(input, UpArrow) || bytes.HasSuffix(input, applicationUpArrow)
 }
 
 // HasDownArrowSuffix test whether input has down arrow suffix
 func HasDownArrowSuffix(input []byte) bool {
-	if len(input) < 3 {
-		return false
-	}
-
-	for i, v := range DownArrow {
-		if input[len(input)-len(DownArrow)+i] != v {
-			return false
-		}
-	}
-
-	return true
+	return bytes.HasSuffix(input, DownArrow) || bytes.HasSuffix(input, applicationDownArrow)
 }
 
 // IsBell test whether input is Bell
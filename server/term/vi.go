@@ -0,0 +1,382 @@
+package term
+
+import (
+	"unicode"
+)
+
+// viState denotes the state of the vi mode, the zero value is the insert mode
+type viState struct {
+	command  bool   // in the command mode, otherwise in the insert mode
+	pending  []rune // the keys of the command being typed
+	lastFind []rune // the last f, F, t or T command with its character, repeated by ; and ,
+}
+
+// handleViKey apply the key in vi mode, and return whether it undoes the last change
+func (in *Input) handleViKey(k key) bool {
+	undoing := false
+	switch {
+	case in.vi.command:
+		undoing = in.handleViCommandKey(k)
+	case k.r == asciiESC:
+		// ESC, switch to the command mode, the cursor moves back onto the last character typed
+		in.vi.command = true
+		in.GoBackOneCharacter()
+	case in.handleCommonKey(k):
+	case k.r == asciiUS:
+		// Ctrl-_, undo the last change
+		in.Undo()
+		undoing = true
+	case k.r < ' ' && k.r != asciiHT:
+		// Other control keys are not bound in the insert mode
+	default:
+		in.insert(k.r)
+	}
+
+	if !in.vi.command {
+		// The text typed in the insert mode is undone together with the command entering it, such as cw
+		in.lastAction = actionInsert
+	}
+	return undoing
+}
+
+func (in *Input) handleViCommandKey(k key) bool {
+	switch k.r {
+	case asciiESC:
+		// ESC, cancel the command being typed
+		in.vi.pending = nil
+		return false
+	case keyUp, keyDown, keyRight, keyLeft, keyHome, keyEnd, keyDelete, keyWordRight, keyWordLeft,
+		asciiETX, asciiNAK, asciiETB, asciiDC2, asciiDC3, asciiDC4, asciiEM, asciiBS, asciiDEL:
+		if len(in.vi.pending) == 0 {
+			if k.r == asciiBS || k.r == asciiDEL {
+				// Backspace only moves the cursor in the command mode
+				in.GoBackOneCharacter()
+			} else {
+				in.handleCommonKey(k)
+			}
+			in.clampViCursor()
+			return false
+		}
+	}
+
+	if k.r < ' ' || k.meta {
+		in.vi.pending = nil
+		return false
+	}
+
+	in.vi.pending = append(in.vi.pending, k.r)
+	complete, undoing := in.runViCommand(in.vi.pending)
+	if complete {
+		in.vi.pending = nil
+		in.clampViCursor()
+	}
+	return undoing
+}
+
+// runViCommand run the vi command, and return whether the command is complete and whether it undoes the last change
+func (in *Input) runViCommand(cmd []rune) (bool, bool) {
+	count, cmd := parseViCount(cmd)
+	if len(cmd) == 0 {
+		return false, false
+	}
+
+	n := count
+	if n == 0 {
+		n = 1
+	}
+	switch c := cmd[0]; c {
+	case 'd', 'c', 'y':
+		motionCount, motion := parseViCount(cmd[1:])
+		if len(motion) == 0 || isViFind(motion[0]) && len(motion) < 2 {
+			return false, false
+		}
+		if motionCount > 0 {
+			n *= motionCount
+		}
+		in.viOperate(c, motion, n)
+	case 'f', 'F', 't', 'T', 'r':
+		if len(cmd) < 2 {
+			return false, false
+		}
+		if c == 'r' {
+			in.viReplace(cmd[1], n)
+		} else if pos, _, ok := in.viMotion(cmd, n, false); ok {
+			in.cursor = pos
+		}
+	case 'i':
+		in.vi.command = false
+	case 'a':
+		in.GoForwardOneCharacter()
+		in.vi.command = false
+	case 'I':
+		in.GoHead()
+		in.vi.command = false
+	case 'A':
+		in.GoEnd()
+		in.vi.command = false
+	case 'x':
+		in.killRange(in.cursor, minInt(in.cursor+n, len(in.buffer)))
+	case 'X':
+		in.killRange(maxInt(in.cursor-n, 0), in.cursor)
+	case 's':
+		in.killRange(in.cursor, minInt(in.cursor+n, len(in.buffer)))
+		in.vi.command = false
+	case 'S':
+		in.viOperate('c', []rune{'c'}, 1)
+	case 'C':
+		in.viOperate('c', []rune{'$'}, 1)
+	case 'D':
+		in.viOperate('d', []rune{'$'}, 1)
+	case 'p', 'P':
+		if len(in.killRing) == 0 {
+			break
+		}
+		if c == 'p' {
+			in.GoForwardOneCharacter()
+		}
+		for i := 0; i < n; i++ {
+			in.insert(in.killRing[len(in.killRing)-1]...)
+		}
+		in.GoBackOneCharacter()
+	case '~':
+		for i := 0; i < n && in.cursor < len(in.buffer); i++ {
+			r := in.buffer[in.cursor]
+			if unicode.IsUpper(r) {
+				in.buffer[in.cursor] = unicode.ToLower(r)
+			} else {
+				in.buffer[in.cursor] = unicode.ToUpper(r)
+			}
+			in.cursor++
+		}
+	case 'u':
+		in.Undo()
+		return true, true
+	case 'k', '-':
+		for i := 0; i < n; i++ {
+			in.PreviousHistory()
+		}
+		in.cursor = 0
+	case 'j', '+':
+		for i := 0; i < n; i++ {
+			in.NextHistory()
+		}
+		in.cursor = 0
+	default:
+		if pos, _, ok := in.viMotion(cmd, n, false); ok {
+			in.cursor = pos
+		}
+	}
+
+	return true, false
+}
+
+// viOperate delete, change or yank the text from the cursor to where the motion goes
+func (in *Input) viOperate(op rune, motion []rune, n int) {
+	start, end := 0, len(in.buffer)
+	if motion[0] != op {
+		// dd, cc and yy operate on the whole line
+		if op == 'c' && (motion[0] == 'w' || motion[0] == 'W') && in.cursor < len(in.buffer) && !isBlank(in.buffer[in.cursor]) {
+			// cw changes to the end of the word like ce
+			motion = []rune{motion[0] - 'w' + 'e'}
+		}
+		pos, inclusive, ok := in.viMotion(motion, n, true)
+		if !ok {
+			return
+		}
+
+		start, end = in.cursor, pos
+		if pos < in.cursor {
+			start, end = pos, in.cursor
+		} else if inclusive {
+			end = minInt(end+1, len(in.buffer))
+		}
+	}
+
+	switch op {
+	case 'y':
+		in.kill(append([]rune(nil), in.buffer[start:end]...), false)
+		in.cursor = start
+	case 'c':
+		in.killRange(start, end)
+		in.vi.command = false
+	default:
+		in.killRange(start, end)
+	}
+}
+
+func (in *Input) viReplace(r rune, n int) {
+	if in.cursor+n > len(in.buffer) {
+		return
+	}
+
+	for i := 0; i < n; i++ {
+		in.buffer[in.cursor+i] = r
+	}
+	in.cursor += n - 1
+}
+
+// viMotion return where the motion goes from the cursor, and whether the character there is included by operators
+func (in *Input) viMotion(motion []rune, n int, operator bool) (int, bool, bool) {
+	end := len(in.buffer)
+	if !operator {
+		end = maxInt(end-1, 0)
+	}
+
+	pos := in.cursor
+	switch m := motion[0]; m {
+	case 'h':
+		return maxInt(pos-n, 0), false, true
+	case 'l', ' ':
+		return minInt(pos+n, end), false, true
+	case '0':
+		return 0, false, true
+	case '^':
+		for pos = 0; pos < len(in.buffer) && isBlank(in.buffer[pos]); pos++ {
+		}
+		return minInt(pos, end), false, true
+	case '$':
+		return end, false, true
+	case '|':
+		return minInt(n-1, end), false, true
+	case 'w', 'W':
+		for i := 0; i < n; i++ {
+			pos = in.viNextWord(pos, m == 'W')
+		}
+		return minInt(pos, end), false, true
+	case 'b', 'B':
+		for i := 0; i < n; i++ {
+			pos = in.viPreviousWord(pos, m == 'B')
+		}
+		return pos, false, true
+	case 'e', 'E':
+		for i := 0; i < n; i++ {
+			pos = in.viWordEnd(pos, m == 'E')
+		}
+		return minInt(pos, maxInt(len(in.buffer)-1, 0)), true, true
+	case 'f', 'F', 't', 'T':
+		if len(motion) < 2 {
+			return 0, false, false
+		}
+		in.vi.lastFind = []rune{m, motion[1]}
+		return in.viFind(m, motion[1], n)
+	case ';', ',':
+		if len(in.vi.lastFind) < 2 {
+			return 0, false, false
+		}
+		m := in.vi.lastFind[0]
+		if motion[0] == ',' {
+			// Reverse the direction of the last find
+			m = map[rune]rune{'f': 'F', 'F': 'f', 't': 'T', 'T': 't'}[m]
+		}
+		return in.viFind(m, in.vi.lastFind[1], n)
+	}
+
+	return 0, false, false
+}
+
+// viFind return where the n-th character r is, f and t search forward, t and T stop before the character
+func (in *Input) viFind(m, r rune, n int) (int, bool, bool) {
+	step := 1
+	if m == 'F' || m == 'T' {
+		step = -1
+	}
+
+	pos := in.cursor
+	for i := 0; i < n; i++ {
+		pos += step
+		for pos >= 0 && pos < len(in.buffer) && in.buffer[pos] != r {
+			pos += step
+		}
+		if pos < 0 || pos >= len(in.buffer) {
+			return 0, false, false
+		}
+	}
+
+	switch m {
+	case 't':
+		pos--
+	case 'T':
+		pos++
+	}
+	return pos, step > 0, true
+}
+
+func (in *Input) viNextWord(pos int, bigWord bool) int {
+	if pos >= len(in.buffer) {
+		return pos
+	}
+
+	if c := viClass(in.buffer[pos], bigWord); c != 0 {
+		for pos < len(in.buffer) && viClass(in.buffer[pos], bigWord) == c {
+			pos++
+		}
+	}
+	for pos < len(in.buffer) && isBlank(in.buffer[pos]) {
+		pos++
+	}
+	return pos
+}
+
+func (in *Input) viPreviousWord(pos int, bigWord bool) int {
+	for pos > 0 && isBlank(in.buffer[pos-1]) {
+		pos--
+	}
+	if pos == 0 {
+		return 0
+	}
+
+	c := viClass(in.buffer[pos-1], bigWord)
+	for pos > 0 && viClass(in.buffer[pos-1], bigWord) == c {
+		pos--
+	}
+	return pos
+}
+
+func (in *Input) viWordEnd(pos int, bigWord bool) int {
+	pos++
+	for pos < len(in.buffer) && isBlank(in.buffer[pos]) {
+		pos++
+	}
+	if pos >= len(in.buffer) {
+		return pos
+	}
+
+	c := viClass(in.buffer[pos], bigWord)
+	for pos+1 < len(in.buffer) && viClass(in.buffer[pos+1], bigWord) == c {
+		pos++
+	}
+	return pos
+}
+
+// clampViCursor keep the cursor on the characters in the command mode
+func (in *Input) clampViCursor() {
+	if in.vi.command && in.cursor >= len(in.buffer) {
+		in.cursor = maxInt(len(in.buffer)-1, 0)
+	}
+}
+
+// viClass return the class of the character for vi word motions, 0 for blanks, 1 for words and 2 for punctuations
+func viClass(r rune, bigWord bool) int {
+	switch {
+	case isBlank(r):
+		return 0
+	case bigWord || r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r):
+		return 1
+	default:
+		return 2
+	}
+}
+
+func isViFind(r rune) bool {
+	return r == 'f' || r == 'F' || r == 't' || r == 'T'
+}
+
+// parseViCount return the count before the vi command, 0 means no count
+func parseViCount(cmd []rune) (int, []rune) {
+	count := 0
+	for len(cmd) > 0 && (cmd[0] >= '1' && cmd[0] <= '9' || count > 0 && cmd[0] == '0') {
+		count = count*10 + int(cmd[0]-'0')
+		cmd = cmd[1:]
+	}
+	return count, cmd
+}
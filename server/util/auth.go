@@ -13,7 +13,14 @@ const (
 	anonymousEmail = "anonymous@anonymous.com"
 )
 
+// adminConsoleRoles are the roles on the console which can write to the stdin of the application's container
+var adminConsoleRoles = map[string]bool{
+	"owner": true,
+	"admin": true,
+}
+
 var (
+	ErrPermissionDenied  = errors.New("permission denied")
 	ErrAuthFailed        = errors.New("authorize failed")
 	ErrAuthNotSupported  = errors.New("entry only works on lain-sso authorization")
 	ErrContainerNotfound = errors.New("get data successfully but not found the container")
@@ -21,26 +28,46 @@ var (
 
 // AuthContainer authorizes whether the client with the token has the right to access the application's container
 func AuthContainer(token, appName string, g *global.Global) (*sso.User, error) {
-	authConfig, err := g.LAINLETClient.ConfigGet("auth/console")
+	user, _, err := authContainer(token, appName, g)
+	return user, err
+}
+
+// AuthContainerAdmin authorizes whether the client with the token is an admin of the application or an owner of entry,
+// which is stricter than AuthContainer since the client can write to the stdin of the main process of the container
+func AuthContainerAdmin(token, appName string, g *global.Global) (*sso.User, error) {
+	user, role, err := authContainer(token, appName, g)
 	if err != nil {
 		return nil, err
 	}
 
+	if user.Email != anonymousEmail && !adminConsoleRoles[role] && !g.SSOClient.IsEntryOwner(*user) {
+		return nil, ErrPermissionDenied
+	}
+
+	return user, nil
+}
+
+func authContainer(token, appName string, g *global.Global) (*sso.User, string, error) {
+	authConfig, err := g.LAINLETClient.ConfigGet("auth/console")
+	if err != nil {
+		return nil, "", err
+	}
+
 	if authStr, exist := authConfig.Data["auth/console"]; exist {
 		c := ConsoleAuthConf{}
 		if err = json.Unmarshal([]byte(authStr), &c); err != nil {
-			return nil, err
+			return nil, "", err
 		}
 		if c.Type == "lain-sso" {
 			authURL := fmt.Sprintf("http://console.%s/api/v1/repos/%s/roles/", g.LAINDomain, appName)
 			return validateConsoleRole(authURL, token, g)
 		}
-		return nil, ErrAuthNotSupported
+		return nil, "", ErrAuthNotSupported
 	}
 
 	return &sso.User{
 		Email: anonymousEmail,
-	}, nil
+	}, "", nil
 }
 
 // AuthAPI authorizes whether the client with this token has right to access the API
@@ -24,7 +24,8 @@ type ConsoleAuthResponse struct {
 	Role    ConsoleRole `json:"role"`
 }
 
-func validateConsoleRole(authURL, token string, g *global.Global) (*sso.User, error) {
+// validateConsoleRole return the user and the role of the user of the application on the console
+func validateConsoleRole(authURL, token string, g *global.Global) (*sso.User, string, error) {
 	var (
 		err       error
 		req       *http.Request
@@ -32,22 +33,23 @@ func validateConsoleRole(authURL, token string, g *global.Global) (*sso.User, er
 		respBytes []byte
 	)
 	if req, err = http.NewRequest("GET", authURL, nil); err != nil {
-		return nil, err
+		return nil, "", err
 	}
 	req.Header.Set("access-token", token)
 	if resp, err = g.HTTPClient.Do(req); err != nil {
-		return nil, err
+		return nil, "", err
 	}
 	defer resp.Body.Close()
 	if respBytes, err = ioutil.ReadAll(resp.Body); err != nil {
-		return nil, err
+		return nil, "", err
 	}
 	caResp := ConsoleAuthResponse{}
 	if err = json.Unmarshal(respBytes, &caResp); err != nil {
-		return nil, err
+		return nil, "", err
 	}
 	if caResp.Role.Role == "" {
-		return nil, ErrAuthFailed
+		return nil, "", ErrAuthFailed
 	}
-	return g.SSOClient.GetMe(token)
+	user, err := g.SSOClient.GetMe(token)
+	return user, caResp.Role.Role, err
 }
@@ -0,0 +1,199 @@
+package util
+
+import (
+	"errors"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fsouza/go-dockerclient"
+	"github.com/laincloud/lainlet/message"
+	"github.com/mijia/sweb/log"
+
+	"github.com/laincloud/entry/server/global"
+)
+
+const (
+	// InstancePolicyLeastLoaded pick the running instance which uses the least CPU among the healthiest ones
+	InstancePolicyLeastLoaded = "least-loaded"
+
+	statsTimeout = 5 * time.Second
+)
+
+var (
+	errNoRunningInstance = errors.New("no running instance is found")
+
+	// healthRanks rank the health status of docker, the instances without health check are better than starting ones
+	healthRanks = map[string]int{
+		"healthy":   0,
+		"":          1,
+		"starting":  2,
+		"unhealthy": 3,
+	}
+)
+
+// IsAnyInstance return whether the instance number is omitted or a wildcard, so that entry should pick one
+func IsAnyInstance(instanceNo string) bool {
+	return instanceNo == "" || instanceNo == "*"
+}
+
+// InstanceCandidate is an instance which can be picked
+type InstanceCandidate struct {
+	InstanceNo string
+	Container  *message.Container
+	Running    bool
+	Health     string
+	Load       float64
+}
+
+// PickContainer pick a running instance of the proc, the healthy ones are preferred
+func PickContainer(appName, procName, policy string, g *global.Global) (string, *message.Container, error) {
+	containers, err := GetProcContainers(appName, procName, g)
+	if err != nil {
+		return "", nil, err
+	}
+
+	candidates := make([]InstanceCandidate, 0, len(containers))
+	for instanceNo, c := range containers {
+		candidates = append(candidates, InstanceCandidate{InstanceNo: instanceNo, Container: c})
+	}
+
+	leastLoaded := policy == InstancePolicyLeastLoaded
+	wg := &sync.WaitGroup{}
+	wg.Add(len(candidates))
+	for i := range candidates {
+		go func(c *InstanceCandidate) {
+			defer wg.Done()
+			container, err := g.DockerClient.InspectContainer(c.Container.Id)
+			if err != nil {
+				log.Errorf("g.DockerClient.InspectContainer(%s) failed, error: %s.", c.Container.Id, err)
+				return
+			}
+
+			c.Running = container.State.Running
+			c.Health = container.State.Health.Status
+			if leastLoaded && c.Running {
+				c.Load = containerLoad(c.Container.Id, g)
+			}
+		}(&candidates[i])
+	}
+	wg.Wait()
+
+	c, ok := pickInstance(candidates, leastLoaded)
+	if !ok {
+		return "", nil, errNoRunningInstance
+	}
+
+	return c.InstanceNo, c.Container, nil
+}
+
+// pickInstance return the running instance with the best health, then the least load if leastLoaded is on,
+// then the least instance number
+func pickInstance(candidates []InstanceCandidate, leastLoaded bool) (InstanceCandidate, bool) {
+	var running []InstanceCandidate
+	for _, c := range candidates {
+		if c.Running {
+			running = append(running, c)
+		}
+	}
+	if len(running) == 0 {
+		return InstanceCandidate{}, false
+	}
+
+	sort.Slice(running, func(i, j int) bool {
+		a, b := running[i], running[j]
+		if healthRanks[a.Health] != healthRanks[b.Health] {
+			return healthRanks[a.Health] < healthRanks[b.Health]
+		}
+
+		if leastLoaded && a.Load != b.Load {
+			return a.Load < b.Load
+		}
+
+		m, _ := strconv.Atoi(a.InstanceNo)
+		n, _ := strconv.Atoi(b.InstanceNo)
+		return m < n
+	})
+	return running[0], true
+}
+
+// containerLoad return the CPU usage of the container, 1 means a whole CPU, it is 0 if the stats are unavailable
+func containerLoad(containerID string, g *global.Global) float64 {
+	statsC := make(chan *docker.Stats, 1)
+	done := make(chan bool)
+	defer close(done)
+	go func() {
+		if err := g.DockerClient.Stats(docker.StatsOptions{
+			ID:      containerID,
+			Stats:   statsC,
+			Stream:  false,
+			Done:    done,
+			Timeout: statsTimeout,
+		}); err != nil {
+			log.Errorf("g.DockerClient.Stats(%s) failed, error: %s.", containerID, err)
+		}
+	}()
+
+	select {
+	case stats, ok := <-statsC:
+		if !ok || stats == nil {
+			return 0
+		}
+
+		cpuDelta := float64(stats.CPUStats.CPUUsage.TotalUsage) - float64(stats.PreCPUStats.CPUUsage.TotalUsage)
+		systemDelta := float64(stats.CPUStats.SystemCPUUsage) - float64(stats.PreCPUStats.SystemCPUUsage)
+		if cpuDelta <= 0 || systemDelta <= 0 {
+			return 0
+		}
+
+		cpus := float64(stats.CPUStats.OnlineCPUs)
+		if cpus == 0 {
+			cpus = float64(len(stats.CPUStats.CPUUsage.PercpuUsage))
+		}
+		return cpuDelta / systemDelta * cpus
+	case <-time.After(statsTimeout):
+		return 0
+	}
+}
+
+// lainContainerName is the name of the containers deployed by LAIN, such as hello.web.web.v0-i1-d0
+var lainContainerName = regexp.MustCompile(`^(.+)\.[^.]+\.([^.]+)\.v\d+-i(\d+)-d\d+$`)
+
+// ParseContainerName parse the app, proc and instance number from the name of a LAIN container
+func ParseContainerName(name string) (string, string, string, bool) {
+	name = name[strings.LastIndex(name, "/")+1:]
+	m := lainContainerName.FindStringSubmatch(name)
+	if m == nil {
+		return "", "", "", false
+	}
+
+	return m[1], m[2], m[3], true
+}
+
+// ResolveContainer find the container by its ID or name, and map it back to the app, proc and instance number
+// if it is the container of a LAIN instance, otherwise they are empty
+func ResolveContainer(ref string, g *global.Global) (string, string, string, *message.Container, error) {
+	c, err := g.DockerClient.InspectContainer(ref)
+	if err != nil {
+		return "", "", "", nil, err
+	}
+
+	container := &message.Container{Id: c.ID}
+	if c.Node != nil {
+		container.NodeIp = c.Node.IP
+	}
+	appName, procName, instanceNo, ok := ParseContainerName(c.Name)
+	if !ok {
+		return "", "", "", container, nil
+	}
+
+	if lainContainer, err := GetContainer(appName, procName, instanceNo, g); err != nil || lainContainer.Id != c.ID {
+		log.Warnf("Container: %s is named like an instance of %s[%s-%s] but it is not.", c.Name, appName, procName, instanceNo)
+		return "", "", "", container, nil
+	}
+
+	return appName, procName, instanceNo, container, nil
+}
@@ -10,8 +10,33 @@ import (
 type Marshaler func(interface{}) ([]byte, error)
 type Unmarshaler func([]byte, interface{}) error
 
+const (
+	EncodingJSON     = "json"
+	EncodingProtobuf = "protobuf"
+)
+
+// GetEncoding return the encoding chosen by the encoding query parameter, method=web implies json
+func GetEncoding(r *http.Request) string {
+	switch encoding := r.URL.Query().Get("encoding"); {
+	case encoding == EncodingJSON || encoding == EncodingProtobuf:
+		return encoding
+	case r.URL.Query().Get("method") == "web":
+		return EncodingJSON
+	default:
+		return EncodingProtobuf
+	}
+}
+
+// DetectEncoding return the encoding of the message
+func DetectEncoding(data []byte) string {
+	if json.Valid(data) {
+		return EncodingJSON
+	}
+	return EncodingProtobuf
+}
+
 func GetMarshalers(r *http.Request) (Marshaler, Unmarshaler) {
-	if r.URL.Query().Get("method") == "web" {
+	if GetEncoding(r) == EncodingJSON {
 		return json.Marshal, json.Unmarshal
 	}
 	return protoMarshalFunc, protoUnmarshalFunc
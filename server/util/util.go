@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 	"unicode/utf8"
 
 	"github.com/laincloud/lainlet/message"
@@ -37,24 +38,36 @@ func getAppProcName(key []string) (string, string) {
 
 // GetContainer get container according to appName, procName and instanceNo
 func GetContainer(appName, procName, instanceNo string, g *global.Global) (*message.Container, error) {
+	containers, err := GetProcContainers(appName, procName, g)
+	if err != nil {
+		return nil, err
+	}
+
+	if container, ok := containers[instanceNo]; ok {
+		return container, nil
+	}
+	return nil, errContainerNotfound
+}
+
+// GetProcContainers get the containers of all instances of the proc, the key is the instance number
+func GetProcContainers(appName, procName string, g *global.Global) (map[string]*message.Container, error) {
 	coreInfo, err := g.LAINLETClient.CoreinfoGet(appName)
 	if err != nil {
 		return nil, err
 	}
 
+	containers := make(map[string]*message.Container)
 	for procFullName, procInfo := range coreInfo.Data {
 		curAppName, curProcName := getAppProcName(strings.Split(procFullName, "."))
 		if curProcName == procName && curAppName == appName {
 			for _, containerInfo := range procInfo.PodInfos {
-				if fmt.Sprint(containerInfo.InstanceNo) == instanceNo &&
-					len(containerInfo.Containers) > 0 &&
-					containerInfo.Containers[0].Id != "" {
-					return containerInfo.Containers[0], nil
+				if len(containerInfo.Containers) > 0 && containerInfo.Containers[0].Id != "" {
+					containers[fmt.Sprint(containerInfo.InstanceNo)] = containerInfo.Containers[0]
 				}
 			}
 		}
 	}
-	return nil, errContainerNotfound
+	return containers, nil
 }
 
 func GetSourceIP(r *http.Request) string {
@@ -98,3 +111,73 @@ func GetWidthAndHeight(data []byte) (int, int) {
 
 	return width, height
 }
+
+// ParseTime parse a timestamp like docker logs --since does, value can be RFC3339 time, unix seconds,
+// or a duration such as 10m which is relative to now
+func ParseTime(value string, now time.Time) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339Nano, value); err == nil {
+		return t, nil
+	}
+
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return time.Unix(seconds, 0), nil
+	}
+
+	if d, err := time.ParseDuration(value); err == nil {
+		return now.Add(-d), nil
+	}
+
+	return time.Time{}, fmt.Errorf("%s is neither a RFC3339 time, unix seconds nor a duration", value)
+}
+
+// InstanceSelector select the instances of a proc by their numbers
+type InstanceSelector struct {
+	all    bool
+	ranges [][2]int
+}
+
+// ParseInstanceSelector parse the selector, which is * or empty for all instances,
+// or comma separated instance numbers and ranges such as 1,3,5-8
+func ParseInstanceSelector(selector string) (*InstanceSelector, error) {
+	selector = strings.TrimSpace(selector)
+	if selector == "" || selector == "*" {
+		return &InstanceSelector{all: true}, nil
+	}
+
+	s := &InstanceSelector{}
+	for _, part := range strings.Split(selector, ",") {
+		bounds := strings.SplitN(strings.TrimSpace(part), "-", 2)
+		from, err := strconv.Atoi(bounds[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid instance selector: %s", selector)
+		}
+
+		to := from
+		if len(bounds) == 2 {
+			if to, err = strconv.Atoi(bounds[1]); err != nil || to < from {
+				return nil, fmt.Errorf("invalid instance selector: %s", selector)
+			}
+		}
+		s.ranges = append(s.ranges, [2]int{from, to})
+	}
+	return s, nil
+}
+
+// Match return whether the instance is selected
+func (s InstanceSelector) Match(instanceNo string) bool {
+	if s.all {
+		return true
+	}
+
+	n, err := strconv.Atoi(instanceNo)
+	if err != nil {
+		return false
+	}
+
+	for _, r := range s.ranges {
+		if n >= r[0] && n <= r[1] {
+			return true
+		}
+	}
+	return false
+}
@@ -1,7 +1,9 @@
 package util
 
 import (
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestGetValidUTF8Length(t *testing.T) {
@@ -19,3 +21,135 @@ func TestGetValidUTF8Length(t *testing.T) {
 		t.Errorf("Case 4 failed: actual is %d", actual)
 	}
 }
+
+func TestParseTime(t *testing.T) {
+	now := time.Date(2017, 10, 1, 12, 0, 0, 0, time.UTC)
+	cases := []struct {
+		value string
+		want  time.Time
+		isErr bool
+	}{
+		{"2017-09-30T08:00:00Z", time.Date(2017, 9, 30, 8, 0, 0, 0, time.UTC), false},
+		{"2017-09-30T08:00:00.5+08:00", time.Date(2017, 9, 30, 0, 0, 0, 500000000, time.UTC), false},
+		{"1506844800", time.Unix(1506844800, 0), false},
+		{"10m", now.Add(-10 * time.Minute), false},
+		{"1h30m", now.Add(-90 * time.Minute), false},
+		{"yesterday", time.Time{}, true},
+		{"", time.Time{}, true},
+	}
+	for _, c := range cases {
+		got, err := ParseTime(c.value, now)
+		if (err != nil) != c.isErr || !got.Equal(c.want) {
+			t.Errorf("ParseTime(%s) == (%v, %v), want: (%v, isErr: %v).", c.value, got, err, c.want, c.isErr)
+		}
+	}
+}
+
+func TestInstanceSelector(t *testing.T) {
+	cases := []struct {
+		selector string
+		isErr    bool
+		matched  string
+	}{
+		{"", false, "1 2 3 4 5"},
+		{"*", false, "1 2 3 4 5"},
+		{"2", false, "2"},
+		{"1, 3-4", false, "1 3 4"},
+		{"5,2-3", false, "2 3 5"},
+		{"3-1", true, ""},
+		{"a", true, ""},
+		{"1,", true, ""},
+	}
+	for _, c := range cases {
+		s, err := ParseInstanceSelector(c.selector)
+		if (err != nil) != c.isErr {
+			t.Errorf("ParseInstanceSelector(%s) == (%v, %v), want error: %v.", c.selector, s, err, c.isErr)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+
+		var matched []string
+		for _, instanceNo := range []string{"1", "2", "3", "4", "5"} {
+			if s.Match(instanceNo) {
+				matched = append(matched, instanceNo)
+			}
+		}
+		if got := strings.Join(matched, " "); got != c.matched {
+			t.Errorf("ParseInstanceSelector(%s) matched %s, want: %s.", c.selector, got, c.matched)
+		}
+	}
+}
+
+func TestPickInstance(t *testing.T) {
+	cases := []struct {
+		candidates  []InstanceCandidate
+		leastLoaded bool
+		want        string
+	}{
+		{
+			candidates: []InstanceCandidate{{InstanceNo: "1"}, {InstanceNo: "2"}},
+			want:       "",
+		},
+		{
+			candidates: []InstanceCandidate{
+				{InstanceNo: "2", Running: true},
+				{InstanceNo: "10", Running: true},
+				{InstanceNo: "1"},
+			},
+			want: "2",
+		},
+		{
+			candidates: []InstanceCandidate{
+				{InstanceNo: "1", Running: true, Health: "unhealthy"},
+				{InstanceNo: "2", Running: true, Health: "starting"},
+				{InstanceNo: "3", Running: true},
+			},
+			want: "3",
+		},
+		{
+			candidates: []InstanceCandidate{
+				{InstanceNo: "1", Running: true, Health: "healthy", Load: 0.9},
+				{InstanceNo: "2", Running: true, Health: "healthy", Load: 0.1},
+				{InstanceNo: "3", Running: true, Load: 0},
+			},
+			want: "1",
+		},
+		{
+			candidates: []InstanceCandidate{
+				{InstanceNo: "1", Running: true, Health: "healthy", Load: 0.9},
+				{InstanceNo: "2", Running: true, Health: "healthy", Load: 0.1},
+				{InstanceNo: "3", Running: true, Load: 0},
+			},
+			leastLoaded: true,
+			want:        "2",
+		},
+	}
+	for _, c := range cases {
+		got, _ := pickInstance(c.candidates, c.leastLoaded)
+		if got.InstanceNo != c.want {
+			t.Errorf("pickInstance(%+v, %v) == %s, want: %s.", c.candidates, c.leastLoaded, got.InstanceNo, c.want)
+		}
+	}
+}
+
+func TestParseContainerName(t *testing.T) {
+	cases := []struct {
+		name                          string
+		appName, procName, instanceNo string
+		ok                            bool
+	}{
+		{"/hello.web.web.v0-i1-d0", "hello", "web", "1", true},
+		{"/node1/hello.worker.queue.v12-i10-d3", "hello", "queue", "10", true},
+		{"resource.redis.hello.web.web.v1-i2-d0", "resource.redis.hello", "web", "2", true},
+		{"/hello.web.web", "", "", "", false},
+		{"/festive_wozniak", "", "", "", false},
+	}
+	for _, c := range cases {
+		appName, procName, instanceNo, ok := ParseContainerName(c.name)
+		if appName != c.appName || procName != c.procName || instanceNo != c.instanceNo || ok != c.ok {
+			t.Errorf("ParseContainerName(%s) == (%s, %s, %s, %v), want: (%s, %s, %s, %v).", c.name, appName, procName, instanceNo, ok, c.appName, c.procName, c.instanceNo, c.ok)
+		}
+	}
+}
@@ -10,15 +10,19 @@ import (
 )
 
 func SendCloseMessage(conn *websocket.Conn, content []byte, msgMarshaller Marshaler, writeLock *sync.Mutex) {
-	closeMsg := &message.ResponseMessage{
+	SendMessage(conn, &message.ResponseMessage{
 		MsgType: message.ResponseMessage_CLOSE,
 		Content: content,
-	}
-	if closeData, err := msgMarshaller(closeMsg); err != nil {
-		log.Errorf("Marshal close message failed: %s", err.Error())
+	}, msgMarshaller, writeLock)
+}
+
+// SendMessage send the message to the client before a pipe is set up
+func SendMessage(conn *websocket.Conn, msg *message.ResponseMessage, msgMarshaller Marshaler, writeLock *sync.Mutex) {
+	if data, err := msgMarshaller(msg); err != nil {
+		log.Errorf("Marshal %s message failed: %s", msg.MsgType, err.Error())
 	} else {
 		writeLock.Lock()
-		conn.WriteMessage(websocket.BinaryMessage, closeData)
+		conn.WriteMessage(websocket.BinaryMessage, data)
 		writeLock.Unlock()
 	}
 }